@@ -1,12 +1,14 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"math/big"
 	"net/http"
 
 	"github.com/SebastianJ/elrond-sdk/utils"
+	"github.com/pkg/errors"
 )
 
 // Account contains the current data for a specific wallet or account
@@ -27,27 +29,52 @@ type AccountWrapper struct {
 
 // GetAccount fetches the desired account's balance as well as nonce
 func (client *Client) GetAccount(address string) (Account, error) {
+	return client.GetAccountContext(context.Background(), address)
+}
+
+// GetAccountContext fetches the desired account's balance as well as nonce using the supplied context
+func (client *Client) GetAccountContext(ctx context.Context, address string) (Account, error) {
+	if client.Cache == nil {
+		return client.fetchAccount(ctx, address)
+	}
+
+	value, err := client.Cache.Get(ctx, CacheAccounts, client.cacheKey(accountCacheKey(address)), func(ctx context.Context) (interface{}, error) {
+		return client.fetchAccount(ctx, address)
+	})
+	if err != nil {
+		return Account{}, err
+	}
+
+	return value.(Account).copy(), nil
+}
+
+func (client *Client) fetchAccount(ctx context.Context, address string) (Account, error) {
 	client.Initialize()
 
-	host := client.Host
+	host := client.host()
 
 	if client.ForceAPINonceLookups {
-		host = defaultEndpoint
+		host = client.apiEndpoint()
 	}
 
-	url := fmt.Sprintf("%s/address/%s", host, address)
-	req, err := http.NewRequest("GET", url, nil)
-
 	var response AccountWrapper
 	var account Account
 
+	url := fmt.Sprintf("%s/address/%s", host, address)
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return account, err
+	}
+
 	body, err := client.PerformRequest(url, req)
 
 	if err != nil {
 		return account, err
 	}
 
-	json.Unmarshal([]byte(body), &response)
+	if err := json.Unmarshal(body, &response); err != nil {
+		return account, errors.Wrapf(err, "JSON Unmarshal")
+	}
 	account = response.Account
 
 	if err := account.Initialize(address); err != nil {
@@ -59,18 +86,38 @@ func (client *Client) GetAccount(address string) (Account, error) {
 
 // GetBalance fetches the balance of a specific account
 func (client *Client) GetBalance(address string) (Account, error) {
+	return client.GetBalanceContext(context.Background(), address)
+}
+
+// GetBalanceContext fetches the balance of a specific account using the supplied context
+func (client *Client) GetBalanceContext(ctx context.Context, address string) (Account, error) {
+	if client.Cache == nil {
+		return client.fetchBalance(ctx, address)
+	}
+
+	value, err := client.Cache.Get(ctx, CacheAccounts, client.cacheKey(balanceCacheKey(address)), func(ctx context.Context) (interface{}, error) {
+		return client.fetchBalance(ctx, address)
+	})
+	if err != nil {
+		return Account{}, err
+	}
+
+	return value.(Account).copy(), nil
+}
+
+func (client *Client) fetchBalance(ctx context.Context, address string) (Account, error) {
 	client.Initialize()
 
-	host := client.Host
+	host := client.host()
 
 	if client.ForceAPINonceLookups {
-		host = defaultEndpoint
+		host = client.apiEndpoint()
 	}
 
 	var account Account
 	url := fmt.Sprintf("%s/address/%s/balance", host, address)
 
-	req, err := http.NewRequest("GET", url, nil)
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
 		return account, err
 	}
@@ -81,7 +128,9 @@ func (client *Client) GetBalance(address string) (Account, error) {
 		return account, err
 	}
 
-	json.Unmarshal([]byte(body), &account)
+	if err := json.Unmarshal(body, &account); err != nil {
+		return account, errors.Wrapf(err, "JSON Unmarshal")
+	}
 
 	if err := account.Initialize(address); err != nil {
 		return account, err
@@ -107,3 +156,12 @@ func (account *Account) Initialize(address string) error {
 
 	return nil
 }
+
+// copy - copies the account so callers can't modify cached balances
+func (account Account) copy() Account {
+	if account.Balance != nil {
+		account.Balance = new(big.Float).Copy(account.Balance)
+	}
+
+	return account
+}
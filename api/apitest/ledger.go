@@ -0,0 +1,156 @@
+package apitest
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+
+	"github.com/ElrondNetwork/elrond-go/core"
+	"github.com/ElrondNetwork/elrond-go/data/transaction"
+	"github.com/SebastianJ/elrond-sdk/api"
+	"github.com/SebastianJ/elrond-sdk/crypto"
+	"github.com/SebastianJ/elrond-sdk/transactions"
+)
+
+// account - the ledger state of an address
+type account struct {
+	nonce   uint64
+	balance *big.Int
+}
+
+// record - a processed transaction
+type record struct {
+	data   api.TransactionData
+	hash   string
+	status api.TransactionStatus
+	nonce  uint64
+}
+
+// rejection - a transaction refused by the ledger, the message matches the one a real node returns
+type rejection struct {
+	message string
+}
+
+func (r rejection) Error() string {
+	return r.message
+}
+
+// account - the state of an address, unknown addresses are empty accounts
+func (node *Node) account(address string) *account {
+	state, ok := node.accounts[address]
+	if !ok {
+		state = &account{balance: new(big.Int)}
+		node.accounts[address] = state
+	}
+
+	return state
+}
+
+// process - validates and executes a transaction, the caller has to hold the node's mutex
+func (node *Node) process(data api.TransactionData) (string, error) {
+	tx, err := node.decode(data)
+	if err != nil {
+		return "", err
+	}
+
+	if err := node.verify(tx); err != nil {
+		return "", err
+	}
+
+	minGasLimit := node.options.GasParams.GasLimit + uint64(len(tx.Data))*node.options.GasParams.GasPerDataByte
+	if tx.GasPrice < node.options.GasParams.GasPrice {
+		return "", rejection{"insufficient gas price in tx"}
+	}
+	if tx.GasLimit < minGasLimit {
+		return "", rejection{"insufficient gas limit in tx"}
+	}
+
+	sender := node.account(data.Sender)
+	switch {
+	case tx.Nonce < sender.nonce:
+		return "", rejection{"lower nonce in transaction"}
+	case tx.Nonce > sender.nonce:
+		return "", rejection{"higher nonce in transaction"}
+	}
+
+	fee := new(big.Int).Mul(new(big.Int).SetUint64(tx.GasPrice), new(big.Int).SetUint64(tx.GasLimit))
+	cost := new(big.Int).Add(tx.Value, fee)
+	if sender.balance.Cmp(cost) < 0 {
+		return "", rejection{"insufficient funds"}
+	}
+
+	hash, err := core.CalculateHash(transactions.InternalMarshalizer, transactions.Hasher, tx)
+	if err != nil {
+		return "", err
+	}
+	hexHash := hex.EncodeToString(hash)
+
+	sender.nonce++
+	sender.balance.Sub(sender.balance, cost)
+	receiver := node.account(data.Receiver)
+	receiver.balance.Add(receiver.balance, tx.Value)
+
+	node.nonce++
+	node.transactions[hexHash] = &record{
+		data:   data,
+		hash:   hexHash,
+		status: api.TxStatusSuccess,
+		nonce:  node.nonce,
+	}
+	node.order = append(node.order, hexHash)
+
+	return hexHash, nil
+}
+
+// decode - converts the API representation to the transaction that was signed
+func (node *Node) decode(data api.TransactionData) (*transaction.Transaction, error) {
+	sender, err := node.converter.Decode(data.Sender)
+	if err != nil {
+		return nil, rejection{fmt.Sprintf("invalid sender address: %s", err)}
+	}
+
+	receiver, err := node.converter.Decode(data.Receiver)
+	if err != nil {
+		return nil, rejection{fmt.Sprintf("invalid receiver address: %s", err)}
+	}
+
+	value, ok := new(big.Int).SetString(data.Value, 10)
+	if !ok || value.Sign() < 0 {
+		return nil, rejection{fmt.Sprintf("invalid transaction value: %s", data.Value)}
+	}
+
+	signature, err := hex.DecodeString(data.Signature)
+	if err != nil {
+		return nil, rejection{"invalid signature encoding"}
+	}
+
+	return &transaction.Transaction{
+		Nonce:     data.Nonce,
+		Value:     value,
+		RcvAddr:   receiver,
+		SndAddr:   sender,
+		GasPrice:  data.GasPrice,
+		GasLimit:  data.GasLimit,
+		Data:      []byte(data.Data),
+		Signature: signature,
+	}, nil
+}
+
+// verify - checks the signature against the same payload transactions.SignTransaction signs
+func (node *Node) verify(tx *transaction.Transaction) error {
+	message, err := tx.GetDataForSigning(node.converter, transactions.TxJSONMarshaler)
+	if err != nil {
+		return err
+	}
+
+	publicKey, err := crypto.NewKeyGenerator(crypto.ED25519).PublicKeyFromByteArray(tx.SndAddr)
+	if err != nil {
+		return rejection{"signature verification failed"}
+	}
+
+	if err := crypto.NewSigner(crypto.ED25519).Verify(publicKey, message, tx.Signature); err != nil {
+		return rejection{"signature verification failed"}
+	}
+
+	return nil
+}
@@ -0,0 +1,389 @@
+// Package apitest provides an in-process fake Elrond node for exercising api.Client and the transactions package offline
+//
+// The node implements the account, balance, send, send-multiple, transaction status, network config and node status
+// endpoints on top of an in-memory ledger. Transactions are verified and executed immediately:
+// signatures are checked against the same payload transactions.SignTransaction signs, nonces and balances are enforced
+// and the sender is charged gasPrice * gasLimit, with the minimums taken from the node's GasParams.
+package apitest
+
+import (
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ElrondNetwork/elrond-go/core"
+	"github.com/ElrondNetwork/elrond-go/core/pubkeyConverter"
+	"github.com/SebastianJ/elrond-sdk/api"
+	"github.com/SebastianJ/elrond-sdk/transactions"
+)
+
+var (
+	// DefaultOptions - the options used for a node unless configured otherwise
+	DefaultOptions = Options{
+		GasParams: transactions.DefaultGasParams,
+		ChainID:   "local-testnet",
+	}
+)
+
+// Options - the configuration of a fake node
+type Options struct {
+	GasParams transactions.GasParams
+	ChainID   string
+}
+
+// Fault - an error response returned instead of handling matching requests
+type Fault struct {
+	// Path - the request path prefix the fault applies to, empty for all requests
+	Path       string
+	StatusCode int
+	Message    string
+	// Times - the number of requests the fault applies to, 0 to keep failing until the faults are cleared
+	Times int
+}
+
+// Node - an in-process fake node backed by an in-memory ledger
+type Node struct {
+	server    *httptest.Server
+	options   Options
+	converter core.PubkeyConverter
+
+	mutex        sync.Mutex
+	accounts     map[string]*account
+	transactions map[string]*record
+	order        []string
+	nonce        uint64
+	latency      time.Duration
+	faults       []*Fault
+	hook         func(request *http.Request)
+}
+
+// NewNode - starts a fake node using the supplied options, close it using Close
+// Fields left at their zero value are taken from DefaultOptions
+func NewNode(options Options) *Node {
+	if options.ChainID == "" {
+		options.ChainID = DefaultOptions.ChainID
+	}
+
+	if options.GasParams.GasPrice == 0 {
+		options.GasParams.GasPrice = DefaultOptions.GasParams.GasPrice
+	}
+
+	if options.GasParams.GasLimit == 0 {
+		options.GasParams.GasLimit = DefaultOptions.GasParams.GasLimit
+	}
+
+	if options.GasParams.GasPerDataByte == 0 {
+		options.GasParams.GasPerDataByte = DefaultOptions.GasParams.GasPerDataByte
+	}
+
+	converter, err := pubkeyConverter.NewBech32PubkeyConverter(32)
+	if err != nil {
+		panic(err)
+	}
+
+	node := &Node{
+		options:      options,
+		converter:    converter,
+		accounts:     make(map[string]*account),
+		transactions: make(map[string]*record),
+	}
+	node.server = httptest.NewServer(node.handler())
+
+	return node
+}
+
+// URL - the base url of the node
+func (node *Node) URL() string {
+	return node.server.URL
+}
+
+// Client - an api client connected to the node
+func (node *Node) Client() api.Client {
+	return api.Client{Host: node.server.URL}
+}
+
+// Close - shuts the node down
+func (node *Node) Close() {
+	node.server.Close()
+}
+
+// SetAccount - sets the nonce and balance of an address
+func (node *Node) SetAccount(address string, nonce uint64, balance *big.Int) {
+	node.mutex.Lock()
+	defer node.mutex.Unlock()
+
+	node.accounts[address] = &account{nonce: nonce, balance: new(big.Int).Set(balance)}
+}
+
+// Fund - adds the amount to the balance of an address
+func (node *Node) Fund(address string, amount *big.Int) {
+	node.mutex.Lock()
+	defer node.mutex.Unlock()
+
+	state := node.account(address)
+	state.balance.Add(state.balance, amount)
+}
+
+// Account - the current nonce and balance of an address
+func (node *Node) Account(address string) (uint64, *big.Int) {
+	node.mutex.Lock()
+	defer node.mutex.Unlock()
+
+	state := node.account(address)
+
+	return state.nonce, new(big.Int).Set(state.balance)
+}
+
+// Transactions - the executed transactions in the order they were processed
+func (node *Node) Transactions() []api.TransactionData {
+	node.mutex.Lock()
+	defer node.mutex.Unlock()
+
+	executed := make([]api.TransactionData, 0, len(node.order))
+	for _, hash := range node.order {
+		executed = append(executed, node.transactions[hash].data)
+	}
+
+	return executed
+}
+
+// SetLatency - delays every response by the supplied duration
+func (node *Node) SetLatency(latency time.Duration) {
+	node.mutex.Lock()
+	defer node.mutex.Unlock()
+
+	node.latency = latency
+}
+
+// InjectFault - fails matching requests with the supplied error response
+func (node *Node) InjectFault(fault Fault) {
+	node.mutex.Lock()
+	defer node.mutex.Unlock()
+
+	node.faults = append(node.faults, &fault)
+}
+
+// ClearFaults - removes all injected faults
+func (node *Node) ClearFaults() {
+	node.mutex.Lock()
+	defer node.mutex.Unlock()
+
+	node.faults = nil
+}
+
+// OnRequest - calls the hook for every incoming request before it's handled, e.g. to count or block requests
+func (node *Node) OnRequest(hook func(request *http.Request)) {
+	node.mutex.Lock()
+	defer node.mutex.Unlock()
+
+	node.hook = hook
+}
+
+func (node *Node) handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/address/", node.handleAddress)
+	mux.HandleFunc("/transaction/send", node.handleSend)
+	mux.HandleFunc("/transaction/send-multiple", node.handleSendMultiple)
+	mux.HandleFunc("/transaction/", node.handleTransaction)
+	mux.HandleFunc("/network/config", node.handleNetworkConfig)
+	mux.HandleFunc("/node/status", node.handleNodeStatus)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		node.mutex.Lock()
+		latency, hook := node.latency, node.hook
+		fault := node.matchFault(r.URL.Path)
+		node.mutex.Unlock()
+
+		if hook != nil {
+			hook(r)
+		}
+
+		if latency > 0 {
+			select {
+			case <-time.After(latency):
+			case <-r.Context().Done():
+				return
+			}
+		}
+
+		if fault != nil {
+			writeError(w, fault.StatusCode, fault.Message)
+			return
+		}
+
+		mux.ServeHTTP(w, r)
+	})
+}
+
+// matchFault - the first active fault for the path, the caller has to hold the node's mutex
+func (node *Node) matchFault(path string) *Fault {
+	for index, fault := range node.faults {
+		if !strings.HasPrefix(path, fault.Path) {
+			continue
+		}
+
+		if fault.Times > 0 {
+			fault.Times--
+			if fault.Times == 0 {
+				node.faults = append(node.faults[:index:index], node.faults[index+1:]...)
+			}
+		}
+
+		return fault
+	}
+
+	return nil
+}
+
+func (node *Node) handleAddress(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	address := strings.TrimPrefix(r.URL.Path, "/address/")
+	balanceOnly := strings.HasSuffix(address, "/balance")
+	address = strings.TrimSuffix(address, "/balance")
+
+	if _, err := node.converter.Decode(address); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid address: "+err.Error())
+		return
+	}
+
+	nonce, balance := node.Account(address)
+	if balanceOnly {
+		writeJSON(w, map[string]interface{}{"balance": balance.String()})
+		return
+	}
+
+	writeJSON(w, map[string]interface{}{
+		"account": map[string]interface{}{
+			"address": address,
+			"nonce":   nonce,
+			"balance": balance.String(),
+		},
+	})
+}
+
+func (node *Node) handleSend(w http.ResponseWriter, r *http.Request) {
+	var data api.TransactionData
+	if err := json.NewDecoder(r.Body).Decode(&data); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid transaction: "+err.Error())
+		return
+	}
+
+	node.mutex.Lock()
+	hash, err := node.process(data)
+	node.mutex.Unlock()
+
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	writeJSON(w, map[string]interface{}{"txHash": hash})
+}
+
+// handleSendMultiple - invalid transactions are skipped, like a real node does
+func (node *Node) handleSendMultiple(w http.ResponseWriter, r *http.Request) {
+	var txs []api.TransactionData
+	if err := json.NewDecoder(r.Body).Decode(&txs); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid transactions: "+err.Error())
+		return
+	}
+
+	hashes := make(map[int]string)
+
+	node.mutex.Lock()
+	for index, data := range txs {
+		if hash, err := node.process(data); err == nil {
+			hashes[index] = hash
+		}
+	}
+	node.mutex.Unlock()
+
+	writeJSON(w, map[string]interface{}{"numOfSentTxs": len(hashes), "txsHashes": hashes})
+}
+
+func (node *Node) handleTransaction(w http.ResponseWriter, r *http.Request) {
+	hash := strings.TrimPrefix(r.URL.Path, "/transaction/")
+	statusOnly := strings.HasSuffix(hash, "/status")
+	hash = strings.TrimSuffix(hash, "/status")
+
+	node.mutex.Lock()
+	tx, ok := node.transactions[hash]
+	node.mutex.Unlock()
+
+	if !ok {
+		writeError(w, http.StatusNotFound, "transaction not found")
+		return
+	}
+
+	if statusOnly {
+		writeJSON(w, map[string]interface{}{"status": tx.status})
+		return
+	}
+
+	writeJSON(w, map[string]interface{}{
+		"transaction": api.TransactionInfo{
+			Hash:       tx.hash,
+			Type:       "normal",
+			Nonce:      tx.data.Nonce,
+			Value:      tx.data.Value,
+			Receiver:   tx.data.Receiver,
+			Sender:     tx.data.Sender,
+			GasPrice:   tx.data.GasPrice,
+			GasLimit:   tx.data.GasLimit,
+			Data:       tx.data.Data,
+			Signature:  tx.data.Signature,
+			Status:     tx.status,
+			BlockNonce: tx.nonce,
+		},
+	})
+}
+
+func (node *Node) handleNetworkConfig(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, map[string]interface{}{
+		"config": api.NetworkConfig{
+			ChainID:        node.options.ChainID,
+			MinGasPrice:    node.options.GasParams.GasPrice,
+			MinGasLimit:    node.options.GasParams.GasLimit,
+			GasPerDataByte: node.options.GasParams.GasPerDataByte,
+			NumShards:      1,
+		},
+	})
+}
+
+func (node *Node) handleNodeStatus(w http.ResponseWriter, r *http.Request) {
+	node.mutex.Lock()
+	nonce := node.nonce
+	node.mutex.Unlock()
+
+	writeJSON(w, map[string]interface{}{
+		"details": api.Metrics{
+			"erd_nonce":                   nonce,
+			"erd_probable_highest_nonce":  nonce,
+			"erd_current_round":           nonce,
+			"erd_synchronized_round":      nonce,
+			"erd_shard_id":                0,
+			"erd_num_shards_without_meta": 1,
+			"erd_is_syncing":              0,
+			"erd_node_type":               "observer",
+		},
+	})
+}
+
+func writeJSON(w http.ResponseWriter, response interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+func writeError(w http.ResponseWriter, statusCode int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(api.ErrorResponse{Error: message, Code: "bad_request"})
+}
@@ -0,0 +1,153 @@
+package apitest_test
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/SebastianJ/elrond-sdk/api"
+	"github.com/SebastianJ/elrond-sdk/api/apitest"
+	"github.com/SebastianJ/elrond-sdk/transactions"
+	"github.com/SebastianJ/elrond-sdk/utils"
+	sdkWallet "github.com/SebastianJ/elrond-sdk/wallet"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSendTransactionEndToEnd(t *testing.T) {
+	t.Parallel()
+
+	node := apitest.NewNode(apitest.DefaultOptions)
+	defer node.Close()
+
+	sender, err := sdkWallet.Generate()
+	assert.Nil(t, err)
+	receiver, err := sdkWallet.Generate()
+	assert.Nil(t, err)
+
+	node.Fund(sender.Address, utils.ConvertFloatAmountToBigInt(10))
+	client := node.Client()
+	gasParams := transactions.DefaultGasParams
+
+	tx, hash, err := transactions.SendTransaction(sender, receiver.Address, 1.5, false, -1, "", gasParams, client)
+	assert.Nil(t, err)
+	assert.Equal(t, tx.TxHash, hash)
+
+	_, _, err = transactions.SendTransaction(sender, receiver.Address, 1, false, -1, "hello", gasParams, client)
+	assert.Nil(t, err)
+
+	fee := func(data string) *big.Int {
+		params := gasParams
+		params.UpdateGasLimit(data)
+		return params.CalculateTotalGasCost()
+	}
+
+	expected := utils.ConvertFloatAmountToBigInt(7.5)
+	expected.Sub(expected, fee(""))
+	expected.Sub(expected, fee("hello"))
+
+	nonce, balance := node.Account(sender.Address)
+	assert.Equal(t, uint64(2), nonce)
+	assert.Equal(t, expected.String(), balance.String())
+
+	_, balance = node.Account(receiver.Address)
+	assert.Equal(t, utils.ConvertFloatAmountToBigInt(2.5).String(), balance.String())
+
+	status, err := client.GetTransactionStatus(hash)
+	assert.Nil(t, err)
+	assert.True(t, status.IsSuccessful())
+	assert.Len(t, node.Transactions(), 2)
+
+	// Sending the maximum amount drains the account completely
+	_, _, err = transactions.SendTransaction(sender, receiver.Address, 0, true, -1, "", gasParams, client)
+	assert.Nil(t, err)
+	_, balance = node.Account(sender.Address)
+	assert.Equal(t, int64(0), balance.Int64())
+}
+
+func TestLedgerRejections(t *testing.T) {
+	t.Parallel()
+
+	node := apitest.NewNode(apitest.DefaultOptions)
+	defer node.Close()
+
+	sender, err := sdkWallet.Generate()
+	assert.Nil(t, err)
+	node.Fund(sender.Address, utils.ConvertFloatAmountToBigInt(1))
+	client := node.Client()
+
+	_, _, err = transactions.SendTransaction(sender, sender.Address, 5, false, -1, "", transactions.DefaultGasParams, client)
+	assert.True(t, errors.Is(err, api.ErrInsufficientFunds))
+
+	lowGas := transactions.DefaultGasParams
+	lowGas.GasLimit = 1000
+	_, _, err = transactions.SendTransaction(sender, sender.Address, 0.1, false, -1, "", lowGas, client)
+	assert.True(t, errors.Is(err, api.ErrGasTooLow))
+
+	tx, err := transactions.GenerateAndSignTransaction(sender, sender.Address, 0.1, false, 3, "", transactions.DefaultGasParams, client)
+	assert.Nil(t, err)
+	_, err = client.SendTransaction(tx.APIData)
+	assert.True(t, errors.Is(err, api.ErrInvalidNonce))
+
+	tx, err = transactions.GenerateAndSignTransaction(sender, sender.Address, 0.1, false, 0, "", transactions.DefaultGasParams, client)
+	assert.Nil(t, err)
+	tx.APIData.Value = utils.ConvertFloatAmountToBigInt(0.2).String()
+	_, err = client.SendTransaction(tx.APIData)
+	assert.True(t, errors.Is(err, api.ErrInvalidSignature))
+
+	// Invalid transactions are skipped when sending multiple transactions
+	valid, err := transactions.GenerateAndSignTransaction(sender, sender.Address, 0.1, false, 0, "", transactions.DefaultGasParams, client)
+	assert.Nil(t, err)
+	response, err := client.SendMultipleTransactions([]*api.TransactionData{tx.APIData, valid.APIData})
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(1), response.TxsSent)
+	assert.Equal(t, valid.TxHash, response.TxsHashes[1])
+}
+
+func TestFaultsAndLatency(t *testing.T) {
+	t.Parallel()
+
+	node := apitest.NewNode(apitest.DefaultOptions)
+	defer node.Close()
+
+	var requests int
+	node.OnRequest(func(request *http.Request) { requests++ })
+	node.InjectFault(apitest.Fault{Path: "/address/", StatusCode: http.StatusServiceUnavailable, Message: "node is syncing", Times: 1})
+
+	wallet, err := sdkWallet.Generate()
+	assert.Nil(t, err)
+	client := node.Client()
+
+	_, err = client.GetAccount(wallet.Address)
+	var apiError *api.Error
+	assert.True(t, errors.As(err, &apiError))
+	assert.Equal(t, http.StatusServiceUnavailable, apiError.StatusCode)
+
+	_, err = client.GetAccount(wallet.Address)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, requests)
+
+	node.SetLatency(200 * time.Millisecond)
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	_, err = client.GetAccountContext(ctx, wallet.Address)
+	assert.NotNil(t, err)
+}
+
+func TestNodeDefaultsOptions(t *testing.T) {
+	t.Parallel()
+
+	node := apitest.NewNode(apitest.Options{GasParams: transactions.GasParams{GasPrice: 2 * apitest.DefaultOptions.GasParams.GasPrice}})
+	defer node.Close()
+
+	client := node.Client()
+	config, err := client.GetNetworkConfig()
+	assert.Nil(t, err)
+	assert.Equal(t, apitest.DefaultOptions.ChainID, config.ChainID)
+	assert.Equal(t, 2*apitest.DefaultOptions.GasParams.GasPrice, config.MinGasPrice)
+	assert.Equal(t, apitest.DefaultOptions.GasParams.GasLimit, config.MinGasLimit)
+	assert.Equal(t, apitest.DefaultOptions.GasParams.GasPerDataByte, config.GasPerDataByte)
+}
@@ -0,0 +1,169 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// MiniBlock - a mini block contained in a shard block
+type MiniBlock struct {
+	Hash             string            `json:"hash"`
+	Type             string            `json:"type"`
+	SourceShard      uint32            `json:"sourceShard"`
+	DestinationShard uint32            `json:"destinationShard"`
+	Transactions     []TransactionInfo `json:"transactions,omitempty"`
+}
+
+// Block - a shard or metachain block
+type Block struct {
+	Nonce         uint64      `json:"nonce"`
+	Round         uint64      `json:"round"`
+	Hash          string      `json:"hash"`
+	PrevBlockHash string      `json:"prevBlockHash"`
+	Epoch         uint32      `json:"epoch"`
+	Shard         uint32      `json:"shard"`
+	NumTxs        uint32      `json:"numTxs"`
+	Timestamp     int64       `json:"timestamp"`
+	Status        string      `json:"status,omitempty"`
+	MiniBlocks    []MiniBlock `json:"miniBlocks,omitempty"`
+}
+
+// ShardBlock - a reference to a shard block notarized by a hyperblock
+type ShardBlock struct {
+	Hash  string `json:"hash"`
+	Nonce uint64 `json:"nonce"`
+	Shard uint32 `json:"shard"`
+}
+
+// HyperBlock - a metachain block together with the transactions of all the shard blocks it notarizes
+type HyperBlock struct {
+	Nonce         uint64            `json:"nonce"`
+	Round         uint64            `json:"round"`
+	Hash          string            `json:"hash"`
+	PrevBlockHash string            `json:"prevBlockHash"`
+	Epoch         uint32            `json:"epoch"`
+	NumTxs        uint32            `json:"numTxs"`
+	Timestamp     int64             `json:"timestamp"`
+	ShardBlocks   []ShardBlock      `json:"shardBlocks,omitempty"`
+	Transactions  []TransactionInfo `json:"transactions,omitempty"`
+}
+
+// GetBlockByNonce fetches the block with the given nonce from a shard
+func (client *Client) GetBlockByNonce(shardID uint32, nonce uint64, withTxs bool) (Block, error) {
+	return client.GetBlockByNonceContext(context.Background(), shardID, nonce, withTxs)
+}
+
+// GetBlockByNonceContext fetches the block with the given nonce from a shard using the supplied context
+func (client *Client) GetBlockByNonceContext(ctx context.Context, shardID uint32, nonce uint64, withTxs bool) (Block, error) {
+	url := fmt.Sprintf("%s/block/%d/by-nonce/%d?withTxs=%t", client.host(), shardID, nonce, withTxs)
+	return client.getBlock(ctx, url)
+}
+
+// GetBlockByHash fetches the block with the given hash from a shard
+func (client *Client) GetBlockByHash(shardID uint32, hash string, withTxs bool) (Block, error) {
+	return client.GetBlockByHashContext(context.Background(), shardID, hash, withTxs)
+}
+
+// GetBlockByHashContext fetches the block with the given hash from a shard using the supplied context
+func (client *Client) GetBlockByHashContext(ctx context.Context, shardID uint32, hash string, withTxs bool) (Block, error) {
+	url := fmt.Sprintf("%s/block/%d/by-hash/%s?withTxs=%t", client.host(), shardID, hash, withTxs)
+	return client.getBlock(ctx, url)
+}
+
+// GetHyperBlockByNonce fetches the hyperblock with the given metachain nonce
+func (client *Client) GetHyperBlockByNonce(nonce uint64) (HyperBlock, error) {
+	return client.GetHyperBlockByNonceContext(context.Background(), nonce)
+}
+
+// GetHyperBlockByNonceContext fetches the hyperblock with the given metachain nonce using the supplied context
+func (client *Client) GetHyperBlockByNonceContext(ctx context.Context, nonce uint64) (HyperBlock, error) {
+	url := fmt.Sprintf("%s/hyperblock/by-nonce/%d", client.host(), nonce)
+	return client.getHyperBlock(ctx, url)
+}
+
+// GetHyperBlockByHash fetches the hyperblock with the given metachain block hash
+func (client *Client) GetHyperBlockByHash(hash string) (HyperBlock, error) {
+	return client.GetHyperBlockByHashContext(context.Background(), hash)
+}
+
+// GetHyperBlockByHashContext fetches the hyperblock with the given metachain block hash using the supplied context
+func (client *Client) GetHyperBlockByHashContext(ctx context.Context, hash string) (HyperBlock, error) {
+	url := fmt.Sprintf("%s/hyperblock/by-hash/%s", client.host(), hash)
+	return client.getHyperBlock(ctx, url)
+}
+
+// Transactions - returns the transactions of all mini blocks in the block
+func (block Block) Transactions() []TransactionInfo {
+	transactions := []TransactionInfo{}
+
+	for _, miniBlock := range block.MiniBlocks {
+		transactions = append(transactions, miniBlock.Transactions...)
+	}
+
+	return transactions
+}
+
+// ContainsTransaction - checks if the block includes the transaction with the given hash
+func (block Block) ContainsTransaction(hash string) bool {
+	return containsTransaction(block.Transactions(), hash)
+}
+
+// ContainsTransaction - checks if the hyperblock includes the transaction with the given hash
+func (hyperBlock HyperBlock) ContainsTransaction(hash string) bool {
+	return containsTransaction(hyperBlock.Transactions, hash)
+}
+
+func (client *Client) getBlock(ctx context.Context, url string) (Block, error) {
+	client.Initialize()
+
+	var block Block
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return block, err
+	}
+
+	// Blocks can be large, so they're decoded while being read
+	err = client.PerformStreamingRequest(url, req, func(body io.Reader) error {
+		block = Block{}
+		return decodeStream(body, "block", &block)
+	})
+	if err != nil {
+		return block, err
+	}
+
+	return block, nil
+}
+
+func (client *Client) getHyperBlock(ctx context.Context, url string) (HyperBlock, error) {
+	client.Initialize()
+
+	var hyperBlock HyperBlock
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return hyperBlock, err
+	}
+
+	err = client.PerformStreamingRequest(url, req, func(body io.Reader) error {
+		hyperBlock = HyperBlock{}
+		return decodeStream(body, "hyperblock", &hyperBlock)
+	})
+	if err != nil {
+		return hyperBlock, err
+	}
+
+	return hyperBlock, nil
+}
+
+func containsTransaction(transactions []TransactionInfo, hash string) bool {
+	for _, transaction := range transactions {
+		if transaction.Hash == hash {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,103 @@
+package api_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/SebastianJ/elrond-sdk/api"
+	"github.com/stretchr/testify/assert"
+)
+
+const testBlock = `{"data":{"block":{"nonce":42,"round":43,"hash":"blockhash","prevBlockHash":"prevhash","epoch":2,"shard":1,"numTxs":3,"timestamp":1600000000,"status":"on-chain","miniBlocks":[
+	{"hash":"mb1","type":"TxBlock","sourceShard":1,"destinationShard":1,"transactions":[{"hash":"tx1","sender":"erd1a","receiver":"erd1b","value":"1"},{"hash":"tx2","sender":"erd1a","receiver":"erd1c","value":"2"}]},
+	{"hash":"mb2","type":"TxBlock","sourceShard":1,"destinationShard":0,"transactions":[{"hash":"tx3","sender":"erd1a","receiver":"erd1d","value":"3"}]},
+	{"hash":"mb3","type":"PeerBlock","sourceShard":1,"destinationShard":1}
+]}},"code":"successful"}`
+
+func newBlockServer(t *testing.T) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/block/1/by-nonce/42", "/block/1/by-hash/blockhash":
+			assert.Equal(t, "true", r.URL.Query().Get("withTxs"))
+			w.Write([]byte(testBlock))
+		case "/hyperblock/by-nonce/100", "/hyperblock/by-hash/hyperhash":
+			w.Write([]byte(`{"data":{"hyperblock":{"nonce":100,"round":101,"hash":"hyperhash","epoch":2,"numTxs":2,"timestamp":1600000006,
+				"shardBlocks":[{"hash":"blockhash","nonce":42,"shard":1},{"hash":"otherhash","nonce":40,"shard":0}],
+				"transactions":[{"hash":"tx1","status":"success"},{"hash":"tx4","status":"success"}]}},"code":"successful"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error":"block not found","code":"internal_issue"}`))
+		}
+	}))
+}
+
+func TestGetBlock(t *testing.T) {
+	t.Parallel()
+
+	server := newBlockServer(t)
+	defer server.Close()
+
+	client := api.Client{Host: server.URL}
+
+	byNonce, err := client.GetBlockByNonce(1, 42, true)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(42), byNonce.Nonce)
+	assert.Equal(t, "blockhash", byNonce.Hash)
+	assert.Equal(t, uint32(1), byNonce.Shard)
+	assert.Equal(t, int64(1600000000), byNonce.Timestamp)
+	assert.Len(t, byNonce.MiniBlocks, 3)
+
+	byHash, err := client.GetBlockByHash(1, "blockhash", true)
+	assert.Nil(t, err)
+	assert.Equal(t, byNonce, byHash)
+
+	_, err = client.GetBlockByNonce(1, 43, true)
+	apiError, ok := err.(*api.Error)
+	assert.True(t, ok)
+	assert.Equal(t, http.StatusNotFound, apiError.StatusCode)
+}
+
+func TestBlockTransactions(t *testing.T) {
+	t.Parallel()
+
+	server := newBlockServer(t)
+	defer server.Close()
+
+	client := api.Client{Host: server.URL}
+	block, err := client.GetBlockByNonce(1, 42, true)
+	assert.Nil(t, err)
+
+	// Transactions are flattened across mini blocks, keeping their order
+	hashes := []string{}
+	for _, transaction := range block.Transactions() {
+		hashes = append(hashes, transaction.Hash)
+	}
+	assert.Equal(t, []string{"tx1", "tx2", "tx3"}, hashes)
+
+	assert.True(t, block.ContainsTransaction("tx3"))
+	assert.False(t, block.ContainsTransaction("tx4"))
+	assert.Empty(t, api.Block{}.Transactions())
+}
+
+func TestGetHyperBlock(t *testing.T) {
+	t.Parallel()
+
+	server := newBlockServer(t)
+	defer server.Close()
+
+	client := api.Client{Host: server.URL}
+
+	byNonce, err := client.GetHyperBlockByNonce(100)
+	assert.Nil(t, err)
+	assert.Equal(t, "hyperhash", byNonce.Hash)
+	assert.Len(t, byNonce.ShardBlocks, 2)
+	assert.Equal(t, uint64(42), byNonce.ShardBlocks[0].Nonce)
+	assert.Len(t, byNonce.Transactions, 2)
+	assert.True(t, byNonce.ContainsTransaction("tx4"))
+	assert.False(t, byNonce.ContainsTransaction("tx2"))
+
+	byHash, err := client.GetHyperBlockByHash("hyperhash")
+	assert.Nil(t, err)
+	assert.Equal(t, byNonce, byHash)
+}
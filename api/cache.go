@@ -0,0 +1,215 @@
+package api
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// CacheAccounts - account and balance lookups
+	CacheAccounts CacheKind = iota
+	// CacheNetworkConfig - network config lookups
+	CacheNetworkConfig
+	// CacheTokenProperties - ESDT token metadata lookups
+	CacheTokenProperties
+)
+
+var (
+	// DefaultCacheTTLs - default time to live for each kind of cached response
+	DefaultCacheTTLs = map[CacheKind]time.Duration{
+		CacheAccounts:        3 * time.Second,
+		CacheNetworkConfig:   10 * time.Minute,
+		CacheTokenProperties: time.Hour,
+	}
+)
+
+// CacheKind - the kind of response being cached, each kind has its own time to live
+type CacheKind int
+
+// Cache - a read-through cache for api reads that also coalesces concurrent identical requests
+type Cache struct {
+	ttls     map[CacheKind]time.Duration
+	mutex    sync.Mutex
+	entries  map[cacheKey]cacheEntry
+	inFlight map[cacheKey]*cacheCall
+	// generation is bumped by every invalidation, fetches started before it aren't stored
+	generation uint64
+}
+
+type cacheKey struct {
+	kind CacheKind
+	key  string
+}
+
+type cacheEntry struct {
+	value   interface{}
+	expires time.Time
+}
+
+type cacheCall struct {
+	done       chan struct{}
+	value      interface{}
+	err        error
+	generation uint64
+}
+
+// detachedContext - keeps the values of its parent but is never cancelled, so a shared fetch outlives its initiator
+type detachedContext struct {
+	parent context.Context
+}
+
+func (detachedContext) Deadline() (time.Time, bool) { return time.Time{}, false }
+func (detachedContext) Done() <-chan struct{}       { return nil }
+func (detachedContext) Err() error                  { return nil }
+
+func (ctx detachedContext) Value(key interface{}) interface{} {
+	return ctx.parent.Value(key)
+}
+
+// NewCache - creates a new cache, kinds without a TTL are never stored but concurrent requests are still coalesced
+func NewCache(ttls map[CacheKind]time.Duration) *Cache {
+	if ttls == nil {
+		ttls = DefaultCacheTTLs
+	}
+
+	return &Cache{
+		ttls:     ttls,
+		entries:  make(map[cacheKey]cacheEntry),
+		inFlight: make(map[cacheKey]*cacheCall),
+	}
+}
+
+// Get - returns a cached value, or fetches it while sharing the fetch with concurrent callers asking for the same key
+// The shared fetch isn't cancelled with the context of the caller that started it, every caller stops waiting when its own context is done
+func (cache *Cache) Get(ctx context.Context, kind CacheKind, key string, fetch func(ctx context.Context) (interface{}, error)) (interface{}, error) {
+	entryKey := cacheKey{kind: kind, key: key}
+
+	cache.mutex.Lock()
+
+	if entry, ok := cache.entries[entryKey]; ok {
+		if time.Now().Before(entry.expires) {
+			cache.mutex.Unlock()
+			return entry.value, nil
+		}
+		delete(cache.entries, entryKey)
+	}
+
+	call, ok := cache.inFlight[entryKey]
+	if !ok {
+		call = &cacheCall{done: make(chan struct{}), generation: cache.generation}
+		cache.inFlight[entryKey] = call
+		go cache.fetch(detachedContext{parent: ctx}, kind, entryKey, call, fetch)
+	}
+	cache.mutex.Unlock()
+
+	select {
+	case <-call.done:
+		return call.value, call.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// fetch - performs a shared fetch, storing the result unless the cache was invalidated in the meantime
+func (cache *Cache) fetch(ctx context.Context, kind CacheKind, entryKey cacheKey, call *cacheCall, fetch func(ctx context.Context) (interface{}, error)) {
+	value, err := fetch(ctx)
+
+	cache.mutex.Lock()
+	call.value, call.err = value, err
+	if cache.inFlight[entryKey] == call {
+		delete(cache.inFlight, entryKey)
+	}
+	if ttl := cache.ttls[kind]; err == nil && ttl > 0 && call.generation == cache.generation {
+		cache.entries[entryKey] = cacheEntry{value: value, expires: time.Now().Add(ttl)}
+	}
+	cache.mutex.Unlock()
+
+	close(call.done)
+}
+
+// Invalidate - removes a single cached value
+func (cache *Cache) Invalidate(kind CacheKind, key string) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	cache.invalidate(cacheKey{kind: kind, key: key})
+}
+
+// InvalidateAccount - removes all cached lookups for an address on every network, e.g. after it sent or received a transaction
+func (cache *Cache) InvalidateAccount(address string) {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	suffixes := []string{cacheKeySeparator + accountCacheKey(address), cacheKeySeparator + balanceCacheKey(address)}
+	matches := func(entryKey cacheKey) bool {
+		if entryKey.kind != CacheAccounts {
+			return false
+		}
+		for _, suffix := range suffixes {
+			if strings.HasSuffix(entryKey.key, suffix) {
+				return true
+			}
+		}
+		return false
+	}
+
+	for entryKey := range cache.entries {
+		if matches(entryKey) {
+			delete(cache.entries, entryKey)
+		}
+	}
+	for entryKey := range cache.inFlight {
+		if matches(entryKey) {
+			delete(cache.inFlight, entryKey)
+		}
+	}
+	cache.generation++
+}
+
+// Clear - removes all cached values
+func (cache *Cache) Clear() {
+	cache.mutex.Lock()
+	defer cache.mutex.Unlock()
+
+	cache.entries = make(map[cacheKey]cacheEntry)
+	cache.inFlight = make(map[cacheKey]*cacheCall)
+	cache.generation++
+}
+
+// invalidate - removes a cached value and detaches the in-flight fetch for it, so new callers fetch a fresh value
+func (cache *Cache) invalidate(entryKey cacheKey) {
+	delete(cache.entries, entryKey)
+	delete(cache.inFlight, entryKey)
+	cache.generation++
+}
+
+// cacheKeySeparator - separates the network a client talks to from the rest of its cache keys
+const cacheKeySeparator = " "
+
+// cacheKey - scopes a cache key to the network the client talks to, so clients of different networks can share a cache
+func (client *Client) cacheKey(key string) string {
+	return client.cacheNamespace() + cacheKeySeparator + key
+}
+
+// cacheNamespace - the network profile name, or else the first pool endpoint or the host
+func (client *Client) cacheNamespace() string {
+	if client.Network != nil && client.Network.Name != "" {
+		return client.Network.Name
+	}
+
+	if client.Pool != nil && len(client.Pool.endpoints) > 0 {
+		return client.Pool.endpoints[0].URL
+	}
+
+	return client.Host
+}
+
+func accountCacheKey(address string) string {
+	return "account:" + address
+}
+
+func balanceCacheKey(address string) string {
+	return "balance:" + address
+}
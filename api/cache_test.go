@@ -0,0 +1,215 @@
+package api_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/SebastianJ/elrond-sdk/api"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCacheServesRepeatedAccountLookups(t *testing.T) {
+	t.Parallel()
+
+	var accountRequests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/transaction/send":
+			w.Write([]byte(`{"txHash":"abc"}`))
+		default:
+			atomic.AddInt32(&accountRequests, 1)
+			w.Write([]byte(`{"account":{"nonce":3,"balance":"1000000000000000000"}}`))
+		}
+	}))
+	defer server.Close()
+
+	client := api.Client{Host: server.URL, Cache: api.NewCache(nil)}
+
+	for i := 0; i < 3; i++ {
+		account, err := client.GetAccount("erd1sender")
+		assert.Nil(t, err)
+		assert.Equal(t, uint64(3), account.Nonce)
+	}
+	assert.Equal(t, int32(1), atomic.LoadInt32(&accountRequests))
+
+	_, err := client.SendTransaction(&api.TransactionData{Sender: "erd1sender", Receiver: "erd1receiver"})
+	assert.Nil(t, err)
+
+	_, err = client.GetAccount("erd1sender")
+	assert.Nil(t, err)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&accountRequests))
+}
+
+func TestCacheCoalescesConcurrentRequests(t *testing.T) {
+	t.Parallel()
+
+	cache := api.NewCache(map[api.CacheKind]time.Duration{api.CacheNetworkConfig: time.Hour})
+
+	var fetches int32
+	started := make(chan struct{})
+	release := make(chan struct{})
+	fetch := func(ctx context.Context) (interface{}, error) {
+		if atomic.AddInt32(&fetches, 1) == 1 {
+			close(started)
+		}
+		<-release
+		return "value", nil
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			value, err := cache.Get(context.Background(), api.CacheNetworkConfig, "config", fetch)
+			assert.Nil(t, err)
+			assert.Equal(t, "value", value)
+		}()
+	}
+
+	<-started
+
+	// Callers joining an in-flight fetch stop waiting when their own context is done
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := cache.Get(ctx, api.CacheNetworkConfig, "config", fetch)
+	assert.Equal(t, context.Canceled, err)
+
+	close(release)
+	wg.Wait()
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&fetches))
+}
+
+func TestCacheSharedFetchOutlivesCancelledCaller(t *testing.T) {
+	t.Parallel()
+
+	cache := api.NewCache(map[api.CacheKind]time.Duration{api.CacheNetworkConfig: time.Hour})
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	fetch := func(ctx context.Context) (interface{}, error) {
+		close(started)
+		<-release
+		return "value", ctx.Err()
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	leader := make(chan error)
+	go func() {
+		_, err := cache.Get(ctx, api.CacheNetworkConfig, "config", fetch)
+		leader <- err
+	}()
+
+	<-started
+	cancel()
+	assert.Equal(t, context.Canceled, <-leader)
+
+	waiter := make(chan interface{})
+	go func() {
+		value, err := cache.Get(context.Background(), api.CacheNetworkConfig, "config", fetch)
+		assert.Nil(t, err)
+		waiter <- value
+	}()
+
+	close(release)
+	assert.Equal(t, "value", <-waiter)
+}
+
+func TestCacheInvalidationDiscardsInFlightFetch(t *testing.T) {
+	t.Parallel()
+
+	cache := api.NewCache(nil)
+
+	var fetches int32
+	started := make(chan struct{})
+	release := make(chan struct{})
+	stale := func(ctx context.Context) (interface{}, error) {
+		atomic.AddInt32(&fetches, 1)
+		close(started)
+		<-release
+		return "stale", nil
+	}
+	fresh := func(ctx context.Context) (interface{}, error) {
+		atomic.AddInt32(&fetches, 1)
+		return "fresh", nil
+	}
+
+	done := make(chan interface{})
+	go func() {
+		value, _ := cache.Get(context.Background(), api.CacheTokenProperties, "TKN-123456", stale)
+		done <- value
+	}()
+
+	<-started
+	cache.Invalidate(api.CacheTokenProperties, "TKN-123456")
+
+	// Callers after the invalidation don't join the stale fetch
+	value, err := cache.Get(context.Background(), api.CacheTokenProperties, "TKN-123456", fresh)
+	assert.Nil(t, err)
+	assert.Equal(t, "fresh", value)
+
+	close(release)
+	assert.Equal(t, "stale", <-done)
+
+	// The stale result didn't overwrite the fresh one
+	value, err = cache.Get(context.Background(), api.CacheTokenProperties, "TKN-123456", stale)
+	assert.Nil(t, err)
+	assert.Equal(t, "fresh", value)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&fetches))
+}
+
+func TestCacheSeparatesNetworks(t *testing.T) {
+	t.Parallel()
+
+	newServer := func(nonce int, chainID string) *httptest.Server {
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Path {
+			case "/network/config":
+				w.Write([]byte(`{"data":{"config":{"erd_chain_id":"` + chainID + `"}}}`))
+			case "/transaction/send":
+				w.Write([]byte(`{"txHash":"abc"}`))
+			default:
+				w.Write([]byte(`{"account":{"nonce":` + strconv.Itoa(nonce) + `,"balance":"0"}}`))
+			}
+		}))
+	}
+	mainnet := newServer(3, "1")
+	defer mainnet.Close()
+	testnet := newServer(7, "T")
+	defer testnet.Close()
+
+	cache := api.NewCache(nil)
+	mainnetClient := api.Client{Host: mainnet.URL, Cache: cache}
+	testnetClient := api.Client{Host: testnet.URL, Cache: cache}
+
+	for _, test := range []struct {
+		client  api.Client
+		nonce   uint64
+		chainID string
+	}{
+		{client: mainnetClient, nonce: 3, chainID: "1"},
+		{client: testnetClient, nonce: 7, chainID: "T"},
+	} {
+		account, err := test.client.GetAccount("erd1sender")
+		assert.Nil(t, err)
+		assert.Equal(t, test.nonce, account.Nonce)
+
+		config, err := test.client.GetNetworkConfig()
+		assert.Nil(t, err)
+		assert.Equal(t, test.chainID, config.ChainID)
+	}
+
+	// Invalidating the sender after a transaction refetches it from the right network
+	_, err := testnetClient.SendTransaction(&api.TransactionData{Sender: "erd1sender", Receiver: "erd1receiver"})
+	assert.Nil(t, err)
+	account, err := mainnetClient.GetAccount("erd1sender")
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(3), account.Nonce)
+}
@@ -1,70 +1,419 @@
 package api
 
 import (
-	"crypto/tls"
+	"encoding/json"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net/http"
 	"net/url"
-	"regexp"
-)
+	"sync"
+	"time"
 
-var (
-	defaultEndpoint     = "https://wallet-api.elrond.com"
-	urlValidationRegexp = regexp.MustCompile(`^http(s)?:\/\/(wallet\-)?api\.elrond\.com`)
+	"github.com/SebastianJ/elrond-sdk/logging"
+	"github.com/pkg/errors"
 )
 
+// configurationMutex - guards the http client configuration of clients shared between goroutines,
+// it's package wide since clients are copied by value and can't hold a lock of their own
+var configurationMutex sync.RWMutex
+
 // Client - client wrapper for communicating with Elrond nodes or the central API
 type Client struct {
 	Host                 string
+	Network              *NetworkProfile
 	ForceAPINonceLookups bool
 	Client               *http.Client
 	Proxy                string
+	TLS                  *TLSConfig
+	RetryPolicy          *RetryPolicy
+	Pool                 *Pool
+	RateLimiter          *RateLimiter
+	Cache                *Cache
+	Middleware           []Middleware
+	MetricsCollector     MetricsCollector
+	Logger               logging.Logger
+	MaxResponseSize      int64
+
+	configured clientConfiguration
+	configErr  error
+}
+
+// clientConfiguration - the settings the http client was last configured with
+type clientConfiguration struct {
+	client *http.Client
+	proxy  string
+	tls    *TLSConfig
 }
 
 // Initialize - initialize the underlying http client
+// Errors in the proxy or TLS settings are returned by the subsequent requests, use Configure to check them upfront
 func (client *Client) Initialize() {
+	client.Configure()
+}
+
+// Configure - initialize the underlying http client and report invalid proxy or TLS settings
+// A caller supplied *http.Client and transport are never modified, the settings are applied to copies of them instead.
+// Transports other than *http.Transport can't be combined with proxy or TLS settings and fail with ErrTransportConflict
+func (client *Client) Configure() error {
+	configurationMutex.RLock()
+	configured, err := client.configurationState()
+	configurationMutex.RUnlock()
+	if configured {
+		return err
+	}
+
+	configurationMutex.Lock()
+	defer configurationMutex.Unlock()
+
+	// Another goroutine sharing the client might have configured it in the meantime
+	if configured, err := client.configurationState(); configured {
+		return err
+	}
+
 	if client.Client == nil {
 		client.Client = &http.Client{}
 	}
 
-	if client.Proxy != "" {
-		proxyURL, _ := url.Parse(client.Proxy)
+	if client.Proxy == "" && client.TLS == nil {
+		return nil
+	}
+
+	current := clientConfiguration{client: client.Client, proxy: client.Proxy, tls: client.TLS}
+	httpClient, err := client.configuredHTTPClient()
+	if err == nil {
+		client.Client = httpClient
+		current.client = httpClient
+	}
+	client.configured, client.configErr = current, err
 
-		transport := &http.Transport{
-			TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
-			Proxy:           http.ProxyURL(proxyURL),
-		}
+	return err
+}
 
-		client.Client.Transport = transport
+// configurationError - the error configuring the client, as long as its settings haven't changed since
+func (client *Client) configurationError() error {
+	configurationMutex.RLock()
+	defer configurationMutex.RUnlock()
+
+	_, err := client.configurationState()
+
+	return err
+}
+
+// configurationState - checks if the http client matches the current settings, and the error configuring it if so
+func (client *Client) configurationState() (bool, error) {
+	if client.Client == nil {
+		return false, nil
+	}
+
+	if client.Proxy == "" && client.TLS == nil {
+		return true, nil
+	}
+
+	if client.configured != (clientConfiguration{client: client.Client, proxy: client.Proxy, tls: client.TLS}) {
+		return false, nil
 	}
+
+	return true, client.configErr
 }
 
-// PerformRequest sends a specified HTTP request
+// configuredHTTPClient - a copy of the http client using a transport with the proxy and TLS settings applied
+func (client *Client) configuredHTTPClient() (*http.Client, error) {
+	var transport *http.Transport
+	var err error
+
+	switch base := client.Client.Transport.(type) {
+	case nil:
+		transport, err = NewTransport(client.Proxy, client.TLS)
+	case *http.Transport:
+		transport, err = configureTransport(base, client.Proxy, client.TLS)
+	default:
+		err = ErrTransportConflict
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	httpClient := *client.Client
+	httpClient.Transport = transport
+
+	return &httpClient, nil
+}
+
+// PerformRequest sends a specified HTTP request and returns the response body
+// Responses with a non 2xx status code are returned as an *Error
+// Failed requests are retried according to the client's RetryPolicy, if one is set
+// Pooled clients fail over to the next selected endpoint between attempts
 func (client *Client) PerformRequest(requestURL string, request *http.Request) ([]byte, error) {
+	var body []byte
+
+	err := client.PerformStreamingRequest(requestURL, request, func(reader io.Reader) (err error) {
+		body, err = ioutil.ReadAll(reader)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return body, nil
+}
+
+// PerformStreamingRequest sends a specified HTTP request and passes the body of a successful response to the handler
+// The body is decompressed and capped at the client's MaxResponseSize, bodies exceeding it fail with ErrResponseTooLarge
+// The handler is called once per successful attempt, so it has to reset any state it keeps between calls
+func (client *Client) PerformStreamingRequest(requestURL string, request *http.Request, handle ResponseHandler) error {
 	request.Header.Set("Content-Type", "application/json; charset=utf-8")
+	if request.Header.Get("Accept-Encoding") == "" {
+		request.Header.Set("Accept-Encoding", "gzip")
+	}
+
+	policy := client.retryPolicy()
+	if policy == nil {
+		return client.performPooledRequest(requestURL, request, handle)
+	}
+
+	for attempt := 1; ; attempt++ {
+		err := client.performPooledRequest(requestURL, request, handle)
+		if err == nil || attempt >= policy.MaxAttempts || !policy.ShouldRetry(request, err) {
+			return err
+		}
+
+		delay := retryDelay(policy, attempt, err)
+		client.Log().Warn("retrying request",
+			logging.F("method", request.Method), logging.URL("url", requestURL),
+			logging.F("attempt", attempt), logging.F("delay", delay), logging.Err(err))
+
+		if sleepErr := sleepContext(request.Context(), delay); sleepErr != nil {
+			return err
+		}
 
-	resp, err := client.Client.Do(request)
+		rewound, rewindErr := rewindRequest(request)
+		if rewindErr != nil {
+			return err
+		}
+		request = rewound
+
+		if client.Pool != nil {
+			rerouted, rerouteErr := client.Pool.reroute(request.URL)
+			if rerouteErr != nil {
+				return err
+			}
+			request.URL = rerouted
+			request.Host = rerouted.Host
+			requestURL = rerouted.String()
+		}
+	}
+}
+
+func (client *Client) performPooledRequest(requestURL string, request *http.Request, handle ResponseHandler) error {
+	if client.Pool == nil {
+		return client.performRequest(requestURL, request, handle)
+	}
+
+	endpoint := client.Pool.endpointFor(request.URL)
+	start := time.Now()
+
+	err := client.performRequest(requestURL, request, handle)
+
+	if endpoint != "" {
+		switch {
+		case err == nil:
+			client.Pool.MarkSuccess(endpoint, time.Since(start))
+		case RetryOnNetworkError(err) || RetryOnServerError(err) || RetryOnTooManyRequests(err):
+			client.Pool.MarkFailure(endpoint)
+		}
+	}
+
+	return err
+}
+
+func (client *Client) performRequest(requestURL string, request *http.Request, handle ResponseHandler) error {
+	if err := client.configurationError(); err != nil {
+		return err
+	}
+
+	if client.RateLimiter != nil {
+		release, err := client.RateLimiter.Acquire(request.Context(), classifyRequest(request))
+		if err != nil {
+			return err
+		}
+		defer release()
+	}
+
+	metrics := client.metrics()
+	endpoint := endpointLabel(request.URL.Path)
+	metrics.RequestStarted(request.Method, endpoint)
+	start := time.Now()
+
+	resp, err := chainMiddleware(client.Client.Do, client.Middleware)(request)
+	if err != nil {
+		err = redactURLError(err)
+		metrics.RequestFinished(request.Method, endpoint, 0, ErrorType(err), time.Since(start))
+		client.Log().Debug("request failed",
+			logging.F("method", request.Method), logging.URL("url", requestURL),
+			logging.F("duration", time.Since(start)), logging.Err(err))
+		return fmt.Errorf("request to url %s failed: %w", logging.RedactURL(requestURL), err)
+	}
+	defer drainAndClose(resp.Body)
+
+	client.Log().Debug("request completed",
+		logging.F("method", request.Method), logging.URL("url", requestURL),
+		logging.F("status", resp.StatusCode), logging.F("duration", time.Since(start)))
+
+	reader, err := client.responseReader(resp)
 	if err != nil {
-		return nil, fmt.Errorf("request to url %s failed", requestURL)
+		metrics.RequestFinished(request.Method, endpoint, resp.StatusCode, ErrorType(err), time.Since(start))
+		return fmt.Errorf("failed to parse response from url %s: %w", logging.RedactURL(requestURL), err)
+	}
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		body, _ := ioutil.ReadAll(io.LimitReader(reader, maxErrorBodySize))
+		apiError := NewError(request, resp.StatusCode, body)
+		apiError.Header = resp.Header
+		apiError.RetryAfter = parseRetryAfter(resp.Header.Get("Retry-After"))
+		metrics.RequestFinished(request.Method, endpoint, resp.StatusCode, ErrorType(apiError), time.Since(start))
+
+		if client.RateLimiter != nil {
+			client.RateLimiter.Defer(classifyRequest(request), apiError.RetryAfter)
+		}
+
+		return apiError
+	}
+
+	if err := handle(reader); err != nil {
+		metrics.RequestFinished(request.Method, endpoint, resp.StatusCode, ErrorType(err), time.Since(start))
+		return fmt.Errorf("failed to parse response from url %s: %w", logging.RedactURL(requestURL), err)
 	}
 
-	body, err := ioutil.ReadAll(resp.Body)
+	metrics.RequestFinished(request.Method, endpoint, resp.StatusCode, "", time.Since(start))
+
+	return nil
+}
+
+// redactURLError - redacts the url the http client includes in its errors, so they can be logged and returned safely
+func redactURLError(err error) error {
+	var urlError *url.Error
+	if errors.As(err, &urlError) {
+		urlError.URL = logging.RedactURL(urlError.URL)
+	}
+
+	return err
+}
+
+// Log - the client's logger, or the SDK wide default logger if none is configured
+func (client *Client) Log() logging.Logger {
+	if client.Logger == nil {
+		return logging.Default()
+	}
+
+	return client.Logger
+}
+
+// retryDelay - the backoff for an attempt, extended to honor a Retry-After returned by the node
+func retryDelay(policy *RetryPolicy, attempt int, err error) time.Duration {
+	delay := policy.Backoff(attempt)
+
+	var apiError *Error
+	if errors.As(err, &apiError) && apiError.RetryAfter > delay {
+		delay = apiError.RetryAfter
+	}
+
+	return delay
+}
+
+// decodeResponse - decodes a field of a node response, which may be wrapped in a data envelope
+func decodeResponse(body []byte, key string, target interface{}) error {
+	raw, ok, err := responseField(body, key)
 	if err != nil {
-		return nil, fmt.Errorf("failed to parse response from url %s", requestURL)
+		return err
 	}
 
-	defer resp.Body.Close()
+	if !ok {
+		return fmt.Errorf("response is missing the %s field", key)
+	}
+
+	return errors.Wrapf(json.Unmarshal(raw, target), "JSON Unmarshal")
+}
+
+// responseField - looks up a field of a node response, which may be wrapped in a data envelope
+func responseField(body []byte, key string) (json.RawMessage, bool, error) {
+	var envelope map[string]json.RawMessage
+	if err := json.Unmarshal(body, &envelope); err != nil {
+		return nil, false, errors.Wrapf(err, "JSON Unmarshal")
+	}
+
+	if data, ok := envelope["data"]; ok && key != "data" {
+		var inner map[string]json.RawMessage
+		if err := json.Unmarshal(data, &inner); err == nil {
+			if _, ok := inner[key]; ok {
+				envelope = inner
+			}
+		}
+	}
+
+	raw, ok := envelope[key]
+
+	return raw, ok, nil
+}
+
+// decodeObject - decodes an object response, which may be returned as is or as a field of a node response
+func decodeObject(body []byte, key string, target interface{}) error {
+	var envelope map[string]json.RawMessage
+	if err := json.Unmarshal(body, &envelope); err != nil {
+		return errors.Wrapf(err, "JSON Unmarshal")
+	}
+
+	_, hasKey := envelope[key]
+	_, hasData := envelope["data"]
+	if hasKey || hasData {
+		return decodeResponse(body, key, target)
+	}
+
+	return errors.Wrapf(json.Unmarshal(body, target), "JSON Unmarshal")
+}
+
+// retryPolicy - pooled clients without an explicit policy get one attempt per endpoint
+func (client *Client) retryPolicy() *RetryPolicy {
+	if client.RetryPolicy != nil || client.Pool == nil {
+		return client.RetryPolicy
+	}
+
+	return &RetryPolicy{
+		MaxAttempts:       client.Pool.Size(),
+		RetryOn:           []RetryPredicate{RetryOnNetworkError, RetryOnServerError, RetryOnTooManyRequests},
+		RetryTransactions: true,
+	}
+}
+
+// host - the host to send the next request to
+func (client *Client) host() string {
+	if client.Pool != nil {
+		return client.Pool.Select()
+	}
 
-	return body, err
+	return client.Host
 }
 
-// UsingOfficialAPI - check if the client is using an official API endpoint
+// UsingOfficialAPI - check if the client is using an official mainnet API endpoint
 func (client *Client) UsingOfficialAPI() bool {
-	matches := urlValidationRegexp.FindAllStringSubmatch(client.Host, -1)
-	if len(matches) > 0 {
-		return true
+	profile, err := LookupNetwork(Mainnet)
+	if err != nil {
+		return false
+	}
+
+	return profile.hasEndpoint(client.Host)
+}
+
+// apiEndpoint - the API endpoint used for forced nonce lookups, defaults to the mainnet API
+func (client *Client) apiEndpoint() string {
+	if client.Network != nil && len(client.Network.Endpoints) > 0 {
+		return client.Network.Endpoints[0]
+	}
+
+	profile, err := LookupNetwork(Mainnet)
+	if err != nil {
+		return client.host()
 	}
 
-	return false
+	return profile.Endpoints[0]
 }
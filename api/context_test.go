@@ -0,0 +1,59 @@
+package api_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/SebastianJ/elrond-sdk/api"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContextAbortsRequests(t *testing.T) {
+	t.Parallel()
+
+	// The server never answers, so only the context can end a request
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-release:
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	client := api.Client{Host: server.URL, RetryPolicy: &api.RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond}}
+
+	calls := map[string]func(ctx context.Context) error{
+		"GetAccountContext": func(ctx context.Context) error {
+			_, err := client.GetAccountContext(ctx, "erd1x")
+			return err
+		},
+		"SendTransactionContext": func(ctx context.Context) error {
+			_, err := client.SendTransactionContext(ctx, &api.TransactionData{})
+			return err
+		},
+		"SendMultipleTransactionsContext": func(ctx context.Context) error {
+			_, err := client.SendMultipleTransactionsContext(ctx, []*api.TransactionData{{}})
+			return err
+		},
+	}
+
+	for name, call := range calls {
+		cancelled, cancel := context.WithCancel(context.Background())
+		cancel()
+		err := call(cancelled)
+		assert.True(t, errors.Is(err, context.Canceled), "%s: %v", name, err)
+
+		expiring, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		start := time.Now()
+		err = call(expiring)
+		cancel()
+		assert.True(t, errors.Is(err, context.DeadlineExceeded), "%s: %v", name, err)
+		assert.True(t, time.Since(start) < 5*time.Second, name)
+	}
+}
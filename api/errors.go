@@ -0,0 +1,113 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/SebastianJ/elrond-sdk/logging"
+	"github.com/pkg/errors"
+)
+
+var (
+	// ErrInvalidNonce - the node rejected a transaction because of its nonce
+	ErrInvalidNonce = errors.New("invalid nonce")
+	// ErrInsufficientFunds - the sender can't cover the value and the gas cost of a transaction
+	ErrInsufficientFunds = errors.New("insufficient funds")
+	// ErrGasTooLow - the gas price or gas limit of a transaction is lower than required
+	ErrGasTooLow = errors.New("gas too low")
+	// ErrInvalidSignature - the signature of a transaction couldn't be verified
+	ErrInvalidSignature = errors.New("invalid signature")
+	// ErrAccountNotFound - the requested account doesn't exist
+	ErrAccountNotFound = errors.New("account not found")
+	// ErrTransactionNotFound - the requested transaction doesn't exist or hasn't been propagated yet
+	ErrTransactionNotFound = errors.New("transaction not found")
+
+	errorClassifiers = []struct {
+		err      error
+		patterns []string
+	}{
+		{err: ErrInvalidNonce, patterns: []string{"invalid nonce", "lower nonce in transaction", "higher nonce in transaction"}},
+		{err: ErrInsufficientFunds, patterns: []string{"insufficient funds"}},
+		{err: ErrGasTooLow, patterns: []string{"insufficient gas", "higher gas limit required", "not enough gas", "insufficient fees"}},
+		{err: ErrInvalidSignature, patterns: []string{"invalid signature", "signature is invalid", "signature verification failed"}},
+		{err: ErrAccountNotFound, patterns: []string{"account not found"}},
+		{err: ErrTransactionNotFound, patterns: []string{"transaction was not found", "transaction not found", "transaction getting failed"}},
+	}
+)
+
+// Error - represents an error returned by a node or the API for a specific request
+type Error struct {
+	StatusCode int
+	Endpoint   string
+	URL        string
+	Body       []byte
+	Code       string
+	Message    string
+	RetryAfter time.Duration
+	Header     http.Header
+	Err        error
+}
+
+// ErrorResponse - the error fields a node includes in its responses
+type ErrorResponse struct {
+	Error string `json:"error,omitempty"`
+	Code  string `json:"code,omitempty"`
+}
+
+// NewError - creates a new error for a failed request, parsing the node's error message and code from the body
+func NewError(request *http.Request, statusCode int, body []byte) *Error {
+	apiError := &Error{
+		StatusCode: statusCode,
+		Body:       body,
+	}
+
+	if request != nil && request.URL != nil {
+		apiError.Endpoint = request.URL.Path
+		apiError.URL = logging.RedactURL(request.URL.String())
+	}
+
+	var response ErrorResponse
+	if err := json.Unmarshal(body, &response); err == nil {
+		apiError.Message = response.Error
+		apiError.Code = response.Code
+	}
+
+	if apiError.Message == "" && statusCode >= http.StatusBadRequest {
+		apiError.Message = http.StatusText(statusCode)
+	}
+
+	apiError.Err = classifyErrorMessage(apiError.Message)
+
+	return apiError
+}
+
+// Error - implements the error interface
+func (apiError *Error) Error() string {
+	if apiError.StatusCode >= http.StatusBadRequest {
+		return fmt.Sprintf("request to %s failed with status %d: %s", apiError.URL, apiError.StatusCode, apiError.Message)
+	}
+
+	return fmt.Sprintf("request to %s failed: %s", apiError.URL, apiError.Message)
+}
+
+// Unwrap - returns the sentinel error matching the node's error message, if any
+func (apiError *Error) Unwrap() error {
+	return apiError.Err
+}
+
+func classifyErrorMessage(message string) error {
+	message = strings.ToLower(message)
+
+	for _, classifier := range errorClassifiers {
+		for _, pattern := range classifier.patterns {
+			if strings.Contains(message, pattern) {
+				return classifier.err
+			}
+		}
+	}
+
+	return nil
+}
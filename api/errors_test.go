@@ -0,0 +1,57 @@
+package api_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/SebastianJ/elrond-sdk/api"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrorClassification(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		message  string
+		sentinel error
+	}{
+		{message: "transaction generation failed: invalid nonce", sentinel: api.ErrInvalidNonce},
+		{message: "transaction generation failed: lower nonce in transaction", sentinel: api.ErrInvalidNonce},
+		{message: "transaction generation failed: insufficient funds", sentinel: api.ErrInsufficientFunds},
+		{message: "transaction generation failed: insufficient gas limit in tx", sentinel: api.ErrGasTooLow},
+		{message: "transaction generation failed: signature is invalid", sentinel: api.ErrInvalidSignature},
+		{message: "could not get requested account: account not found", sentinel: api.ErrAccountNotFound},
+	}
+
+	for _, test := range tests {
+		body := []byte(`{"error":"` + test.message + `","code":"bad_request"}`)
+		request := httptest.NewRequest("POST", "http://localhost/transaction/send", nil)
+		apiError := api.NewError(request, http.StatusBadRequest, body)
+
+		assert.True(t, errors.Is(apiError, test.sentinel), test.message)
+		assert.Equal(t, "/transaction/send", apiError.Endpoint)
+		assert.Equal(t, "bad_request", apiError.Code)
+		assert.Equal(t, test.message, apiError.Message)
+	}
+}
+
+func TestSendTransactionReturnsTypedError(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"error":"transaction generation failed: invalid nonce"}`))
+	}))
+	defer server.Close()
+
+	client := api.Client{Host: server.URL}
+	_, err := client.SendTransaction(&api.TransactionData{})
+
+	var apiError *api.Error
+	assert.True(t, errors.As(err, &apiError))
+	assert.Equal(t, http.StatusBadRequest, apiError.StatusCode)
+	assert.True(t, errors.Is(err, api.ErrInvalidNonce))
+	assert.False(t, errors.Is(err, api.ErrInsufficientFunds))
+}
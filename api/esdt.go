@@ -0,0 +1,186 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+
+	"github.com/SebastianJ/elrond-sdk/utils"
+)
+
+// TokenBalance - the balance an address holds of an ESDT token
+type TokenBalance struct {
+	TokenIdentifier string     `json:"tokenIdentifier"`
+	BalanceString   string     `json:"balance"`
+	Balance         *big.Float `json:"-"`
+	Properties      string     `json:"properties,omitempty"`
+}
+
+// TokenProperties - the metadata of an ESDT token
+type TokenProperties struct {
+	Identifier     string `json:"identifier"`
+	Name           string `json:"name"`
+	Ticker         string `json:"ticker"`
+	Owner          string `json:"owner"`
+	Decimals       int    `json:"decimals"`
+	Supply         string `json:"supply,omitempty"`
+	Minted         string `json:"minted,omitempty"`
+	Burnt          string `json:"burnt,omitempty"`
+	IsPaused       bool   `json:"isPaused"`
+	CanUpgrade     bool   `json:"canUpgrade"`
+	CanMint        bool   `json:"canMint"`
+	CanBurn        bool   `json:"canBurn"`
+	CanChangeOwner bool   `json:"canChangeOwner"`
+	CanPause       bool   `json:"canPause"`
+	CanFreeze      bool   `json:"canFreeze"`
+	CanWipe        bool   `json:"canWipe"`
+}
+
+// GetTokenBalances fetches all ESDT token balances of an address, keyed by token identifier
+func (client *Client) GetTokenBalances(address string) (map[string]TokenBalance, error) {
+	return client.GetTokenBalancesContext(context.Background(), address)
+}
+
+// GetTokenBalancesContext fetches all ESDT token balances of an address using the supplied context
+func (client *Client) GetTokenBalancesContext(ctx context.Context, address string) (map[string]TokenBalance, error) {
+	client.Initialize()
+
+	url := fmt.Sprintf("%s/address/%s/esdt", client.host(), address)
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := client.PerformRequest(url, req)
+	if err != nil {
+		return nil, err
+	}
+
+	balances := make(map[string]TokenBalance)
+	if err := decodeResponse(body, "esdts", &balances); err != nil {
+		return nil, err
+	}
+
+	for identifier, balance := range balances {
+		if balance.TokenIdentifier == "" {
+			balance.TokenIdentifier = identifier
+			balances[identifier] = balance
+		}
+	}
+
+	return balances, nil
+}
+
+// GetTokenBalance fetches the balance an address holds of a specific ESDT token
+func (client *Client) GetTokenBalance(address string, identifier string) (TokenBalance, error) {
+	return client.GetTokenBalanceContext(context.Background(), address, identifier)
+}
+
+// GetTokenBalanceContext fetches the balance an address holds of a specific ESDT token using the supplied context
+func (client *Client) GetTokenBalanceContext(ctx context.Context, address string, identifier string) (TokenBalance, error) {
+	client.Initialize()
+
+	var balance TokenBalance
+
+	url := fmt.Sprintf("%s/address/%s/esdt/%s", client.host(), address, identifier)
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return balance, err
+	}
+
+	body, err := client.PerformRequest(url, req)
+	if err != nil {
+		return balance, err
+	}
+
+	if err := decodeResponse(body, "tokenData", &balance); err != nil {
+		return balance, err
+	}
+
+	if balance.TokenIdentifier == "" {
+		balance.TokenIdentifier = identifier
+	}
+
+	return balance, nil
+}
+
+// GetTokenProperties fetches the metadata of an ESDT token from the /tokens/{identifier} route of the Elrond API
+// Nodes don't serve this route, so the client's host has to point to the Elrond API (e.g. https://api.elrond.com)
+func (client *Client) GetTokenProperties(identifier string) (TokenProperties, error) {
+	return client.GetTokenPropertiesContext(context.Background(), identifier)
+}
+
+// GetTokenPropertiesContext fetches the metadata of an ESDT token from the /tokens/{identifier} route of the Elrond API using the supplied context
+func (client *Client) GetTokenPropertiesContext(ctx context.Context, identifier string) (TokenProperties, error) {
+	if client.Cache == nil {
+		return client.fetchTokenProperties(ctx, identifier)
+	}
+
+	value, err := client.Cache.Get(ctx, CacheTokenProperties, client.cacheKey(identifier), func(ctx context.Context) (interface{}, error) {
+		return client.fetchTokenProperties(ctx, identifier)
+	})
+	if err != nil {
+		return TokenProperties{}, err
+	}
+
+	return value.(TokenProperties), nil
+}
+
+func (client *Client) fetchTokenProperties(ctx context.Context, identifier string) (TokenProperties, error) {
+	client.Initialize()
+
+	var properties TokenProperties
+
+	url := fmt.Sprintf("%s/tokens/%s", client.host(), identifier)
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return properties, err
+	}
+
+	body, err := client.PerformRequest(url, req)
+	if err != nil {
+		return properties, err
+	}
+
+	if err := decodeObject(body, "token", &properties); err != nil {
+		return properties, err
+	}
+
+	if properties.Identifier == "" {
+		properties.Identifier = identifier
+	}
+
+	if properties.Ticker == "" {
+		properties.Ticker = strings.SplitN(properties.Identifier, "-", 2)[0]
+	}
+
+	return properties, nil
+}
+
+// Initialize - converts the balance using the token's number of decimals
+func (balance *TokenBalance) Initialize(decimals int) error {
+	if balance.BalanceString == "" {
+		return nil
+	}
+
+	converted, err := utils.ConvertNumeralStringToBigFloatWithDecimals(balance.BalanceString, decimals)
+	if err != nil {
+		return err
+	}
+
+	balance.Balance = converted
+
+	return nil
+}
+
+// ToBaseUnits - converts an amount of the token to its smallest, indivisible unit
+func (properties TokenProperties) ToBaseUnits(amount float64) *big.Int {
+	return utils.ConvertFloatAmountToBigIntWithDecimals(amount, properties.Decimals)
+}
+
+// FromBaseUnits - converts an amount in the token's smallest unit to a decimal amount
+func (properties TokenProperties) FromBaseUnits(amount string) (*big.Float, error) {
+	return utils.ConvertNumeralStringToBigFloatWithDecimals(amount, properties.Decimals)
+}
@@ -0,0 +1,86 @@
+package api_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/SebastianJ/elrond-sdk/api"
+	"github.com/stretchr/testify/assert"
+)
+
+func newESDTServer() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/address/erd1holder/esdt":
+			w.Write([]byte(`{"data":{"esdts":{"TKN-123456":{"tokenIdentifier":"TKN-123456","balance":"1500000"},"OTHER-abcdef":{"balance":"7"}}},"code":"successful"}`))
+		case "/address/erd1holder/esdt/TKN-123456":
+			w.Write([]byte(`{"data":{"tokenData":{"balance":"1500000","properties":"01"}},"code":"successful"}`))
+		case "/tokens/TKN-123456":
+			w.Write([]byte(`{"identifier":"TKN-123456","name":"Token","owner":"erd1owner","decimals":6,"isPaused":false,"canMint":true,"canBurn":true}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestGetTokenBalances(t *testing.T) {
+	t.Parallel()
+
+	server := newESDTServer()
+	defer server.Close()
+
+	client := api.Client{Host: server.URL}
+	balances, err := client.GetTokenBalances("erd1holder")
+	assert.Nil(t, err)
+	assert.Len(t, balances, 2)
+	assert.Equal(t, "1500000", balances["TKN-123456"].BalanceString)
+
+	// The identifier is filled in from the key when the node omits it
+	assert.Equal(t, "OTHER-abcdef", balances["OTHER-abcdef"].TokenIdentifier)
+	assert.Equal(t, "7", balances["OTHER-abcdef"].BalanceString)
+}
+
+func TestGetTokenBalance(t *testing.T) {
+	t.Parallel()
+
+	server := newESDTServer()
+	defer server.Close()
+
+	client := api.Client{Host: server.URL}
+	balance, err := client.GetTokenBalance("erd1holder", "TKN-123456")
+	assert.Nil(t, err)
+	assert.Equal(t, "TKN-123456", balance.TokenIdentifier)
+	assert.Equal(t, "01", balance.Properties)
+	assert.Nil(t, balance.Balance)
+
+	assert.Nil(t, balance.Initialize(6))
+	converted, _ := balance.Balance.Float64()
+	assert.Equal(t, 1.5, converted)
+
+	_, err = client.GetTokenBalance("erd1holder", "MISSING-000000")
+	assert.NotNil(t, err)
+}
+
+func TestGetTokenProperties(t *testing.T) {
+	t.Parallel()
+
+	server := newESDTServer()
+	defer server.Close()
+
+	client := api.Client{Host: server.URL}
+	properties, err := client.GetTokenProperties("TKN-123456")
+	assert.Nil(t, err)
+	assert.Equal(t, "Token", properties.Name)
+	assert.Equal(t, "TKN", properties.Ticker)
+	assert.Equal(t, "erd1owner", properties.Owner)
+	assert.Equal(t, 6, properties.Decimals)
+	assert.True(t, properties.CanMint)
+	assert.False(t, properties.CanPause)
+
+	assert.Equal(t, "2500000", properties.ToBaseUnits(2.5).String())
+	amount, err := properties.FromBaseUnits("2500000")
+	assert.Nil(t, err)
+	converted, _ := amount.Float64()
+	assert.Equal(t, 2.5, converted)
+}
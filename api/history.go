@@ -0,0 +1,211 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+const (
+	// DirectionAny - transactions both sent from and received by an address
+	DirectionAny Direction = iota
+	// DirectionOutgoing - transactions sent from an address
+	DirectionOutgoing
+	// DirectionIncoming - transactions received by an address
+	DirectionIncoming
+)
+
+var (
+	// DefaultHistoryPageSize - the page size used when a history query doesn't specify one
+	DefaultHistoryPageSize = 25
+)
+
+// Direction - filters account history by the role the address had in a transaction
+type Direction int
+
+// HistoryQuery - a page of an account's transaction history
+type HistoryQuery struct {
+	Address   string
+	Direction Direction
+	// From is the offset of the first transaction to return
+	From int
+	// Size is the number of transactions per page, defaults to DefaultHistoryPageSize
+	Size   int
+	After  time.Time
+	Before time.Time
+}
+
+// HistoryTransaction - a transaction as returned by the account history endpoint
+type HistoryTransaction struct {
+	Hash          string            `json:"txHash"`
+	Nonce         uint64            `json:"nonce"`
+	Round         uint64            `json:"round"`
+	Value         string            `json:"value"`
+	Fee           string            `json:"fee,omitempty"`
+	Sender        string            `json:"sender"`
+	Receiver      string            `json:"receiver"`
+	SenderShard   uint32            `json:"senderShard"`
+	ReceiverShard uint32            `json:"receiverShard"`
+	GasPrice      uint64            `json:"gasPrice"`
+	GasLimit      uint64            `json:"gasLimit"`
+	GasUsed       uint64            `json:"gasUsed,omitempty"`
+	Data          string            `json:"data,omitempty"`
+	MiniBlockHash string            `json:"miniBlockHash,omitempty"`
+	Status        TransactionStatus `json:"status"`
+	Timestamp     int64             `json:"timestamp"`
+}
+
+// HistoryPage - a page of transactions together with the query used to fetch it
+type HistoryPage struct {
+	Query        HistoryQuery
+	Transactions []HistoryTransaction
+	HasMore      bool
+}
+
+// HistoryIterator - walks all pages of an account's transaction history
+// Pages are fetched by offset, so transactions processed while iterating can shift the history
+// and cause entries to be returned twice or skipped
+type HistoryIterator struct {
+	client  *Client
+	ctx     context.Context
+	query   HistoryQuery
+	page    []HistoryTransaction
+	index   int
+	current HistoryTransaction
+	done    bool
+	err     error
+}
+
+// GetTransactionHistory fetches a page of an account's transaction history
+// The history is served by the Elrond API, so the client's host has to point to it rather than to a node
+func (client *Client) GetTransactionHistory(query HistoryQuery) (HistoryPage, error) {
+	return client.GetTransactionHistoryContext(context.Background(), query)
+}
+
+// GetTransactionHistoryContext fetches a page of an account's transaction history using the supplied context
+func (client *Client) GetTransactionHistoryContext(ctx context.Context, query HistoryQuery) (HistoryPage, error) {
+	client.Initialize()
+
+	if query.Size <= 0 {
+		query.Size = DefaultHistoryPageSize
+	}
+
+	page := HistoryPage{Query: query}
+
+	url := fmt.Sprintf("%s/accounts/%s/transactions?%s", client.host(), query.Address, query.values().Encode())
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return page, err
+	}
+
+	// History pages can be large, so they're decoded while being read
+	err = client.PerformStreamingRequest(url, req, func(body io.Reader) error {
+		page.Transactions = nil
+		return decodeStream(body, "transactions", &page.Transactions)
+	})
+	if err != nil {
+		return page, err
+	}
+
+	page.HasMore = len(page.Transactions) >= query.Size
+
+	return page, nil
+}
+
+// IterateTransactionHistory returns an iterator walking all pages matching the query
+func (client *Client) IterateTransactionHistory(query HistoryQuery) *HistoryIterator {
+	return client.IterateTransactionHistoryContext(context.Background(), query)
+}
+
+// IterateTransactionHistoryContext returns an iterator walking all pages matching the query using the supplied context
+func (client *Client) IterateTransactionHistoryContext(ctx context.Context, query HistoryQuery) *HistoryIterator {
+	return &HistoryIterator{
+		client: client,
+		ctx:    ctx,
+		query:  query,
+	}
+}
+
+// NextQuery - the query fetching the page following this one
+func (page HistoryPage) NextQuery() HistoryQuery {
+	next := page.Query
+	next.From += len(page.Transactions)
+
+	return next
+}
+
+// Time - the time the transaction was processed
+func (transaction HistoryTransaction) Time() time.Time {
+	return time.Unix(transaction.Timestamp, 0)
+}
+
+// Next - advances to the next transaction, fetching the next page when needed
+// Returns false when all transactions have been read or an error occurred
+func (iterator *HistoryIterator) Next() bool {
+	if iterator.err != nil {
+		return false
+	}
+
+	if iterator.index >= len(iterator.page) {
+		if iterator.done {
+			return false
+		}
+
+		page, err := iterator.client.GetTransactionHistoryContext(iterator.ctx, iterator.query)
+		if err != nil {
+			iterator.err = err
+			return false
+		}
+
+		iterator.page = page.Transactions
+		iterator.index = 0
+		iterator.query = page.NextQuery()
+		iterator.done = !page.HasMore
+
+		if len(iterator.page) == 0 {
+			return false
+		}
+	}
+
+	iterator.current = iterator.page[iterator.index]
+	iterator.index++
+
+	return true
+}
+
+// Transaction - the transaction the iterator currently points to
+func (iterator *HistoryIterator) Transaction() HistoryTransaction {
+	return iterator.current
+}
+
+// Err - the error that stopped the iteration, if any
+func (iterator *HistoryIterator) Err() error {
+	return iterator.err
+}
+
+func (query HistoryQuery) values() url.Values {
+	values := url.Values{}
+	values.Set("from", strconv.Itoa(query.From))
+	values.Set("size", strconv.Itoa(query.Size))
+
+	switch query.Direction {
+	case DirectionOutgoing:
+		values.Set("sender", query.Address)
+	case DirectionIncoming:
+		values.Set("receiver", query.Address)
+	}
+
+	if !query.After.IsZero() {
+		values.Set("after", strconv.FormatInt(query.After.Unix(), 10))
+	}
+
+	if !query.Before.IsZero() {
+		values.Set("before", strconv.FormatInt(query.Before.Unix(), 10))
+	}
+
+	return values
+}
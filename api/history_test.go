@@ -0,0 +1,58 @@
+package api_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/SebastianJ/elrond-sdk/api"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIterateTransactionHistory(t *testing.T) {
+	t.Parallel()
+
+	total := 5
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/accounts/erd1test/transactions", r.URL.Path)
+		assert.Equal(t, "erd1test", r.URL.Query().Get("receiver"))
+
+		from, _ := strconv.Atoi(r.URL.Query().Get("from"))
+		size, _ := strconv.Atoi(r.URL.Query().Get("size"))
+
+		transactions := []string{}
+		for i := from; i < from+size && i < total; i++ {
+			transactions = append(transactions, fmt.Sprintf(`{"txHash":"hash%d","nonce":%d,"status":"success"}`, i, i))
+		}
+
+		w.Write([]byte("[" + strings.Join(transactions, ",") + "]"))
+	}))
+	defer server.Close()
+
+	client := api.Client{Host: server.URL}
+	iterator := client.IterateTransactionHistory(api.HistoryQuery{
+		Address:   "erd1test",
+		Direction: api.DirectionIncoming,
+		Size:      2,
+	})
+
+	hashes := []string{}
+	for iterator.Next() {
+		hashes = append(hashes, iterator.Transaction().Hash)
+		assert.Equal(t, api.TxStatusSuccess, iterator.Transaction().Status)
+	}
+
+	assert.Nil(t, iterator.Err())
+	assert.Equal(t, []string{"hash0", "hash1", "hash2", "hash3", "hash4"}, hashes)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	iterator = client.IterateTransactionHistoryContext(ctx, api.HistoryQuery{Address: "erd1test", Direction: api.DirectionIncoming})
+	assert.False(t, iterator.Next())
+	assert.True(t, errors.Is(iterator.Err(), context.Canceled))
+}
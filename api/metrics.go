@@ -0,0 +1,146 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// TransactionAccepted - the outcome reported for transactions the node accepted, others are reported with their ErrorType
+	TransactionAccepted = "accepted"
+	// TransactionRejected - the ErrorType of a request the node answered but refused without a more specific reason,
+	// e.g. the transactions of a batch that weren't sent
+	TransactionRejected = "rejected"
+)
+
+// MetricsCollector - receives instrumentation events from the client
+// Endpoints are request paths with addresses, hashes, nonces and other identifiers replaced by placeholders
+type MetricsCollector interface {
+	// RequestStarted - a request attempt to the endpoint is about to be sent
+	RequestStarted(method string, endpoint string)
+	// RequestFinished - a request attempt completed, statusCode is 0 and errorType is set when no response was received
+	RequestFinished(method string, endpoint string, statusCode int, errorType string, duration time.Duration)
+	// TransactionSubmitted - a transaction was submitted, outcome is TransactionAccepted or the ErrorType of the failure
+	TransactionSubmitted(outcome string)
+}
+
+// NoopMetrics - a metrics collector discarding all events, used when the client has no collector configured
+var NoopMetrics MetricsCollector = noopMetrics{}
+
+type noopMetrics struct{}
+
+func (noopMetrics) RequestStarted(string, string)                              {}
+func (noopMetrics) RequestFinished(string, string, int, string, time.Duration) {}
+func (noopMetrics) TransactionSubmitted(string)                                {}
+
+var (
+	sentinelErrorTypes = []struct {
+		err       error
+		errorType string
+	}{
+		{err: ErrInvalidNonce, errorType: "invalid_nonce"},
+		{err: ErrInsufficientFunds, errorType: "insufficient_funds"},
+		{err: ErrGasTooLow, errorType: "gas_too_low"},
+		{err: ErrInvalidSignature, errorType: "invalid_signature"},
+		{err: ErrAccountNotFound, errorType: "account_not_found"},
+		{err: ErrTransactionNotFound, errorType: "transaction_not_found"},
+	}
+
+	endpointSegments = map[string]bool{
+		"accounts": true, "transactions": true, "address": true, "balance": true, "esdt": true,
+		"block": true, "by-hash": true, "by-nonce": true, "hyperblock": true, "network": true,
+		"config": true, "node": true, "heartbeatstatus": true, "status": true, "tokens": true,
+		"transaction": true, "cost": true, "send": true, "send-multiple": true, "simulate": true,
+		"validator": true, "statistics": true, "vm-values": true, "query": true,
+	}
+)
+
+// ErrorType - a short, low cardinality description of an error, e.g. timeout, server_error or invalid_nonce
+func ErrorType(err error) string {
+	if err == nil {
+		return ""
+	}
+
+	for _, sentinel := range sentinelErrorTypes {
+		if errors.Is(err, sentinel.err) {
+			return sentinel.errorType
+		}
+	}
+
+	var apiError *Error
+	var netError net.Error
+	var syntaxError *json.SyntaxError
+	var typeError *json.UnmarshalTypeError
+
+	switch {
+	case errors.Is(err, context.Canceled):
+		return "canceled"
+	case errors.Is(err, context.DeadlineExceeded):
+		return "timeout"
+	case errors.As(err, &apiError):
+		switch {
+		case apiError.StatusCode == 429:
+			return "rate_limited"
+		case apiError.StatusCode >= 500:
+			return "server_error"
+		case apiError.StatusCode >= 400:
+			return "client_error"
+		case apiError.StatusCode >= 200 && apiError.StatusCode < 300:
+			return TransactionRejected
+		}
+		return "api_error"
+	case errors.As(err, &syntaxError), errors.As(err, &typeError):
+		return "invalid_response"
+	case errors.As(err, &netError) && netError.Timeout():
+		return "timeout"
+	case errors.As(err, &netError):
+		return "network"
+	}
+
+	return "other"
+}
+
+// endpointLabel - the request path with identifiers replaced by placeholders, e.g. /address/{address}/balance
+func endpointLabel(path string) string {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+
+	for index, segment := range segments {
+		switch {
+		case segment == "" || endpointSegments[segment]:
+		case strings.HasPrefix(segment, "erd1"):
+			segments[index] = "{address}"
+		case strings.Trim(segment, "0123456789") == "":
+			segments[index] = "{number}"
+		default:
+			segments[index] = "{id}"
+		}
+	}
+
+	return "/" + strings.Join(segments, "/")
+}
+
+// metrics - the client's metrics collector, or the no-op collector if none is configured
+func (client *Client) metrics() MetricsCollector {
+	if client.MetricsCollector == nil {
+		return NoopMetrics
+	}
+
+	return client.MetricsCollector
+}
+
+// recordTransactions - reports the outcome of submitting transactions
+func (client *Client) recordTransactions(count int, err error) {
+	outcome := TransactionAccepted
+	if err != nil {
+		outcome = ErrorType(err)
+	}
+
+	for i := 0; i < count; i++ {
+		client.metrics().TransactionSubmitted(outcome)
+	}
+}
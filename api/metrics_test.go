@@ -0,0 +1,149 @@
+package api_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/SebastianJ/elrond-sdk/api"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrometheusMetrics(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/transaction/send":
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"error":"lower nonce in transaction"}`))
+		case "/transaction/send-multiple":
+			w.Write([]byte(`{"numOfSentTxs":1,"txsHashes":{"0":"abcd"}}`))
+		case "/node/status":
+			w.WriteHeader(http.StatusServiceUnavailable)
+		default:
+			w.Write([]byte(`{"account":{"nonce":1}}`))
+		}
+	}))
+	defer server.Close()
+
+	metrics := api.NewPrometheusMetrics("", 0.5, 0.1)
+	client := api.Client{Host: server.URL, MetricsCollector: metrics}
+
+	_, err := client.GetAccount("erd1qyu5wthldzr8wx5c9ucg8kjagg0jfs53s8nr3zpz3hypefsdd8ssycr6th")
+	assert.Nil(t, err)
+	_, err = client.GetAccount("erd1spyavw0956vq68xj8y4tenjpq2wd5a9p2c6j8gsz7ztyrnpxrruqzu66jx")
+	assert.Nil(t, err)
+	_, err = client.Status()
+	assert.NotNil(t, err)
+	_, err = client.SendTransaction(&api.TransactionData{})
+	assert.NotNil(t, err)
+	_, err = client.SendMultipleTransactions([]*api.TransactionData{{}, {}})
+	assert.Nil(t, err)
+
+	var buffer bytes.Buffer
+	written, err := metrics.WriteTo(&buffer)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(buffer.Len()), written)
+
+	output := buffer.String()
+	assert.Contains(t, output, "# TYPE elrond_sdk_requests_total counter\n")
+	assert.Contains(t, output, `elrond_sdk_requests_total{method="GET",endpoint="/address/{address}",code="200"} 2`)
+	assert.Contains(t, output, `elrond_sdk_request_errors_total{method="GET",endpoint="/node/status",type="server_error"} 1`)
+	assert.Contains(t, output, `elrond_sdk_request_errors_total{method="POST",endpoint="/transaction/send",type="invalid_nonce"} 1`)
+	assert.Contains(t, output, `elrond_sdk_requests_in_flight{method="GET",endpoint="/address/{address}"} 0`)
+	assert.Contains(t, output, `elrond_sdk_transactions_total{outcome="accepted"} 1`)
+	assert.Contains(t, output, `elrond_sdk_transactions_total{outcome="invalid_nonce"} 1`)
+	assert.Contains(t, output, `elrond_sdk_transactions_total{outcome="rejected"} 1`)
+	assert.Contains(t, output, `elrond_sdk_request_duration_seconds_bucket{method="GET",endpoint="/address/{address}",le="0.1"} 2`)
+	assert.Contains(t, output, `elrond_sdk_request_duration_seconds_bucket{method="GET",endpoint="/address/{address}",le="+Inf"} 2`)
+	assert.Contains(t, output, `elrond_sdk_request_duration_seconds_count{method="GET",endpoint="/address/{address}"} 2`)
+
+	recorder := httptest.NewRecorder()
+	metrics.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	assert.Contains(t, recorder.Header().Get("Content-Type"), "text/plain")
+	assert.Equal(t, output, recorder.Body.String())
+}
+
+type outcomeCollector struct {
+	mutex    sync.Mutex
+	outcomes []string
+}
+
+func (collector *outcomeCollector) RequestStarted(string, string)                              {}
+func (collector *outcomeCollector) RequestFinished(string, string, int, string, time.Duration) {}
+
+func (collector *outcomeCollector) TransactionSubmitted(outcome string) {
+	collector.mutex.Lock()
+	defer collector.mutex.Unlock()
+
+	collector.outcomes = append(collector.outcomes, outcome)
+}
+
+func (collector *outcomeCollector) take() []string {
+	collector.mutex.Lock()
+	defer collector.mutex.Unlock()
+
+	outcomes := collector.outcomes
+	collector.outcomes = nil
+
+	return outcomes
+}
+
+func TestTransactionOutcomeMetrics(t *testing.T) {
+	t.Parallel()
+
+	var response atomic.Value
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(response.Load().(string)))
+	}))
+	defer server.Close()
+
+	collector := &outcomeCollector{}
+	client := api.Client{Host: server.URL, MetricsCollector: collector}
+
+	// Single and batch rejections are labelled the same way
+	response.Store(`{"error":"transaction generation failed"}`)
+	_, err := client.SendTransaction(&api.TransactionData{})
+	assert.NotNil(t, err)
+	assert.Equal(t, []string{api.TransactionRejected}, collector.take())
+
+	response.Store(`{"numOfSentTxs":1,"txsHashes":{"0":"abcd"}}`)
+	_, err = client.SendMultipleTransactions([]*api.TransactionData{{}, {}})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{api.TransactionAccepted, api.TransactionRejected}, collector.take())
+
+	response.Store(`{"error":"invalid nonce"}`)
+	_, err = client.SendMultipleTransactions([]*api.TransactionData{{}, {}})
+	assert.NotNil(t, err)
+	assert.Equal(t, []string{"invalid_nonce", "invalid_nonce"}, collector.take())
+
+	// Responses that can't be decoded are still reported, since the transaction was submitted
+	response.Store(`not json`)
+	_, err = client.SendTransaction(&api.TransactionData{})
+	assert.NotNil(t, err)
+	assert.Equal(t, []string{"invalid_response"}, collector.take())
+
+	_, err = client.SendMultipleTransactions([]*api.TransactionData{{}, {}})
+	assert.NotNil(t, err)
+	assert.Equal(t, []string{"invalid_response", "invalid_response"}, collector.take())
+}
+
+func TestErrorType(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, "", api.ErrorType(nil))
+	assert.Equal(t, "timeout", api.ErrorType(context.DeadlineExceeded))
+	assert.Equal(t, "canceled", api.ErrorType(context.Canceled))
+	assert.Equal(t, "rate_limited", api.ErrorType(api.NewError(nil, http.StatusTooManyRequests, nil)))
+	assert.Equal(t, "insufficient_funds", api.ErrorType(api.NewError(nil, http.StatusBadRequest, []byte(`{"error":"insufficient funds"}`))))
+	assert.Equal(t, "client_error", api.ErrorType(api.NewError(nil, http.StatusNotFound, nil)))
+	assert.Equal(t, api.TransactionRejected, api.ErrorType(api.NewError(nil, http.StatusOK, []byte(`{"error":"unknown"}`))))
+	assert.Equal(t, "invalid_response", api.ErrorType(json.Unmarshal([]byte(`not json`), &struct{}{})))
+}
@@ -0,0 +1,95 @@
+package api
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"time"
+)
+
+// RequestHandler - sends a request and returns its response, the signature shared by every middleware layer
+type RequestHandler func(request *http.Request) (*http.Response, error)
+
+// Middleware - wraps the handler sending requests, e.g. to modify outgoing requests or inspect responses
+type Middleware func(next RequestHandler) RequestHandler
+
+// RequestObserver - gets notified about every completed request attempt
+type RequestObserver func(request *http.Request, response *http.Response, err error, duration time.Duration)
+
+// WithHeader - sets a header on every outgoing request
+func WithHeader(key string, value string) Middleware {
+	return func(next RequestHandler) RequestHandler {
+		return func(request *http.Request) (*http.Response, error) {
+			request.Header.Set(key, value)
+			return next(request)
+		}
+	}
+}
+
+// WithUserAgent - sets the User-Agent of every outgoing request
+func WithUserAgent(userAgent string) Middleware {
+	return WithHeader("User-Agent", userAgent)
+}
+
+// WithBearerToken - authenticates every outgoing request using a bearer token
+func WithBearerToken(token string) Middleware {
+	return WithHeader("Authorization", "Bearer "+token)
+}
+
+// WithBasicAuth - authenticates every outgoing request using basic auth
+func WithBasicAuth(username string, password string) Middleware {
+	return func(next RequestHandler) RequestHandler {
+		return func(request *http.Request) (*http.Response, error) {
+			request.SetBasicAuth(username, password)
+			return next(request)
+		}
+	}
+}
+
+// WithRequestID - sets a unique X-Request-ID header on every request that doesn't already have one
+// The generator defaults to random hex identifiers when nil
+func WithRequestID(generator func() string) Middleware {
+	if generator == nil {
+		generator = randomRequestID
+	}
+
+	return func(next RequestHandler) RequestHandler {
+		return func(request *http.Request) (*http.Response, error) {
+			if request.Header.Get("X-Request-ID") == "" {
+				request.Header.Set("X-Request-ID", generator())
+			}
+			return next(request)
+		}
+	}
+}
+
+// WithObserver - reports every request attempt, e.g. for logging, metrics or tracing
+func WithObserver(observer RequestObserver) Middleware {
+	return func(next RequestHandler) RequestHandler {
+		return func(request *http.Request) (*http.Response, error) {
+			start := time.Now()
+			response, err := next(request)
+			observer(request, response, err, time.Since(start))
+
+			return response, err
+		}
+	}
+}
+
+// chainMiddleware - wraps the handler so the first middleware is the outermost one
+func chainMiddleware(handler RequestHandler, middleware []Middleware) RequestHandler {
+	for index := len(middleware) - 1; index >= 0; index-- {
+		handler = middleware[index](handler)
+	}
+
+	return handler
+}
+
+func randomRequestID() string {
+	buffer := make([]byte, 16)
+	if _, err := rand.Read(buffer); err != nil {
+		return time.Now().Format("20060102150405.000000000")
+	}
+
+	return hex.EncodeToString(buffer)
+}
@@ -0,0 +1,88 @@
+package api_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/SebastianJ/elrond-sdk/api"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMiddlewareChain(t *testing.T) {
+	t.Parallel()
+
+	var headers http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		headers = r.Header.Clone()
+		w.Write([]byte(`{"account":{"nonce":1}}`))
+	}))
+	defer server.Close()
+
+	var order []string
+	tag := func(name string) api.Middleware {
+		return func(next api.RequestHandler) api.RequestHandler {
+			return func(request *http.Request) (*http.Response, error) {
+				order = append(order, name)
+				return next(request)
+			}
+		}
+	}
+
+	var observed int
+	client := api.Client{
+		Host: server.URL,
+		Middleware: []api.Middleware{
+			tag("outer"),
+			tag("inner"),
+			api.WithUserAgent("elrond-sdk-test"),
+			api.WithBearerToken("secret"),
+			api.WithRequestID(func() string { return "request-1" }),
+			api.WithObserver(func(request *http.Request, response *http.Response, err error, duration time.Duration) {
+				assert.Nil(t, err)
+				assert.Equal(t, http.StatusOK, response.StatusCode)
+				observed++
+			}),
+		},
+	}
+
+	account, err := client.GetAccount("erd1test")
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(1), account.Nonce)
+
+	assert.Equal(t, []string{"outer", "inner"}, order)
+	assert.Equal(t, 1, observed)
+	assert.Equal(t, "elrond-sdk-test", headers.Get("User-Agent"))
+	assert.Equal(t, "Bearer secret", headers.Get("Authorization"))
+	assert.Equal(t, "request-1", headers.Get("X-Request-ID"))
+	assert.Contains(t, headers.Get("Content-Type"), "application/json")
+}
+
+func TestMiddlewareFaultInjection(t *testing.T) {
+	t.Parallel()
+
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Write([]byte(`{"account":{"nonce":1}}`))
+	}))
+	defer server.Close()
+
+	injected := errors.New("injected failure")
+	client := api.Client{
+		Host: server.URL,
+		Middleware: []api.Middleware{
+			func(next api.RequestHandler) api.RequestHandler {
+				return func(request *http.Request) (*http.Response, error) {
+					return nil, injected
+				}
+			},
+		},
+	}
+
+	_, err := client.GetAccount("erd1test")
+	assert.True(t, errors.Is(err, injected))
+	assert.Equal(t, 0, requests)
+}
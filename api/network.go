@@ -0,0 +1,113 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"time"
+)
+
+const (
+	// MetachainShardID - the shard ID of the metachain
+	MetachainShardID uint32 = math.MaxUint32
+)
+
+// NetworkConfig - the network wide configuration exposed by a node
+type NetworkConfig struct {
+	ChainID                  string `json:"erd_chain_id"`
+	MinGasPrice              uint64 `json:"erd_min_gas_price"`
+	MinGasLimit              uint64 `json:"erd_min_gas_limit"`
+	GasPerDataByte           uint64 `json:"erd_gas_per_data_byte"`
+	NumShards                uint32 `json:"erd_num_shards_without_meta"`
+	NumNodesInShard          uint32 `json:"erd_num_nodes_in_shard"`
+	NumMetachainNodes        uint32 `json:"erd_num_metachain_nodes"`
+	ShardConsensusGroupSize  uint32 `json:"erd_shard_consensus_group_size"`
+	MetaConsensusGroupSize   uint32 `json:"erd_meta_consensus_group_size"`
+	RoundDuration            uint64 `json:"erd_round_duration"`
+	StartTime                uint64 `json:"erd_start_time"`
+	LatestTagSoftwareVersion string `json:"erd_latest_tag_software_version"`
+}
+
+// GetNetworkConfig fetches the network configuration from the node
+func (client *Client) GetNetworkConfig() (NetworkConfig, error) {
+	return client.GetNetworkConfigContext(context.Background())
+}
+
+// GetNetworkConfigContext fetches the network configuration from the node using the supplied context
+func (client *Client) GetNetworkConfigContext(ctx context.Context) (NetworkConfig, error) {
+	if client.Cache == nil {
+		return client.fetchNetworkConfig(ctx)
+	}
+
+	value, err := client.Cache.Get(ctx, CacheNetworkConfig, client.cacheKey("config"), func(ctx context.Context) (interface{}, error) {
+		return client.fetchNetworkConfig(ctx)
+	})
+	if err != nil {
+		return NetworkConfig{}, err
+	}
+
+	return value.(NetworkConfig), nil
+}
+
+func (client *Client) fetchNetworkConfig(ctx context.Context) (NetworkConfig, error) {
+	client.Initialize()
+
+	var config NetworkConfig
+
+	url := fmt.Sprintf("%s/network/config", client.host())
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return config, err
+	}
+
+	body, err := client.PerformRequest(url, req)
+	if err != nil {
+		return config, err
+	}
+
+	if err := decodeResponse(body, "config", &config); err != nil {
+		return config, err
+	}
+
+	return config, nil
+}
+
+// GetNetworkStatus fetches the status of a shard, use MetachainShardID for the metachain
+func (client *Client) GetNetworkStatus(shardID uint32) (NodeStatus, error) {
+	return client.GetNetworkStatusContext(context.Background(), shardID)
+}
+
+// GetNetworkStatusContext fetches the status of a shard using the supplied context, use MetachainShardID for the metachain
+func (client *Client) GetNetworkStatusContext(ctx context.Context, shardID uint32) (NodeStatus, error) {
+	client.Initialize()
+
+	var status NodeStatus
+
+	url := fmt.Sprintf("%s/network/status/%d", client.host(), shardID)
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return status, err
+	}
+
+	body, err := client.PerformRequest(url, req)
+	if err != nil {
+		return status, err
+	}
+
+	if err := decodeResponse(body, "status", &status); err != nil {
+		return status, err
+	}
+
+	return status, nil
+}
+
+// RoundDurationTime - the duration of a round (the node reports it in milliseconds)
+func (config NetworkConfig) RoundDurationTime() time.Duration {
+	return time.Duration(config.RoundDuration) * time.Millisecond
+}
+
+// StartTimestamp - the time the network (genesis) was started
+func (config NetworkConfig) StartTimestamp() time.Time {
+	return time.Unix(int64(config.StartTime), 0)
+}
@@ -1,17 +1,66 @@
 package api
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
 )
 
+// Metrics - raw node metrics keyed by their metric name, e.g. erd_nonce
+type Metrics map[string]interface{}
+
+// NodeStatus - the status metrics reported by a node
+type NodeStatus struct {
+	ShardID                  uint32
+	EpochNumber              uint32
+	CurrentRound             uint64
+	SynchronizedRound        uint64
+	Nonce                    uint64
+	ProbableHighestNonce     uint64
+	NonceAtEpochStart        uint64
+	RoundAtEpochStart        uint64
+	RoundsPerEpoch           uint64
+	RoundTime                uint64
+	CrossCheckBlockHeight    string
+	CurrentBlockHash         string
+	IsSyncing                bool
+	PeerType                 string
+	NodeType                 string
+	NodeDisplayName          string
+	PublicKeyBlockSign       string
+	AppVersion               string
+	LatestTagSoftwareVersion string
+	NumConnectedPeers        uint64
+	ConnectedNodes           uint64
+	LiveValidatorNodes       uint64
+	NumValidators            uint64
+	NumShards                uint32
+	CountConsensus           uint64
+	CountLeader              uint64
+	CountAcceptedBlocks      uint64
+	ConsensusState           string
+	TxPoolLoad               uint64
+	// Metrics contains every metric reported by the node, including the ones without a dedicated field
+	Metrics Metrics
+}
+
 // Status - get the actual node status
 func (client *Client) Status() (map[string]interface{}, error) {
+	return client.StatusContext(context.Background())
+}
+
+// StatusContext - get the actual node status using the supplied context
+func (client *Client) StatusContext(ctx context.Context) (map[string]interface{}, error) {
 	client.Initialize()
 
-	url := fmt.Sprintf("%s/node/status", client.Host)
-	req, err := http.NewRequest("GET", url, nil)
+	url := fmt.Sprintf("%s/node/status", client.host())
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -28,3 +77,189 @@ func (client *Client) Status() (map[string]interface{}, error) {
 
 	return jsonMap, nil
 }
+
+// GetNodeStatus - get the typed node status
+func (client *Client) GetNodeStatus() (NodeStatus, error) {
+	return client.GetNodeStatusContext(context.Background())
+}
+
+// GetNodeStatusContext - get the typed node status using the supplied context
+func (client *Client) GetNodeStatusContext(ctx context.Context) (NodeStatus, error) {
+	client.Initialize()
+
+	var status NodeStatus
+
+	url := fmt.Sprintf("%s/node/status", client.host())
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return status, err
+	}
+
+	body, err := client.PerformRequest(url, req)
+	if err != nil {
+		return status, err
+	}
+
+	// Older nodes return the metrics as details, newer nodes as metrics
+	details, ok, err := responseField(body, "details")
+	if err != nil {
+		return status, err
+	}
+
+	if !ok {
+		return status, decodeResponse(body, "metrics", &status)
+	}
+
+	return status, errors.Wrapf(json.Unmarshal(details, &status), "JSON Unmarshal")
+}
+
+// UnmarshalJSON - decodes the node metrics, tolerating numbers and booleans encoded as strings and vice versa
+func (status *NodeStatus) UnmarshalJSON(data []byte) error {
+	metrics := make(Metrics)
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&metrics); err != nil {
+		return err
+	}
+
+	*status = NewNodeStatus(metrics)
+
+	return nil
+}
+
+// MarshalJSON - encodes the status as node metrics, so it decodes back to the same status
+// Metrics without a dedicated field are kept, fields take precedence over the metrics they were decoded from
+func (status NodeStatus) MarshalJSON() ([]byte, error) {
+	metrics := make(Metrics, len(status.Metrics))
+	for key, value := range status.Metrics {
+		metrics[key] = value
+	}
+
+	for key, value := range map[string]interface{}{
+		"erd_shard_id":                    status.ShardID,
+		"erd_epoch_number":                status.EpochNumber,
+		"erd_current_round":               status.CurrentRound,
+		"erd_synchronized_round":          status.SynchronizedRound,
+		"erd_nonce":                       status.Nonce,
+		"erd_probable_highest_nonce":      status.ProbableHighestNonce,
+		"erd_nonce_at_epoch_start":        status.NonceAtEpochStart,
+		"erd_round_at_epoch_start":        status.RoundAtEpochStart,
+		"erd_rounds_per_epoch":            status.RoundsPerEpoch,
+		"erd_round_time":                  status.RoundTime,
+		"erd_cross_check_block_height":    status.CrossCheckBlockHeight,
+		"erd_current_block_hash":          status.CurrentBlockHash,
+		"erd_is_syncing":                  status.IsSyncing,
+		"erd_peer_type":                   status.PeerType,
+		"erd_node_type":                   status.NodeType,
+		"erd_node_display_name":           status.NodeDisplayName,
+		"erd_public_key_block_sign":       status.PublicKeyBlockSign,
+		"erd_app_version":                 status.AppVersion,
+		"erd_latest_tag_software_version": status.LatestTagSoftwareVersion,
+		"erd_num_connected_peers":         status.NumConnectedPeers,
+		"erd_connected_nodes":             status.ConnectedNodes,
+		"erd_live_validator_nodes":        status.LiveValidatorNodes,
+		"erd_num_validators":              status.NumValidators,
+		"erd_num_shards_without_meta":     status.NumShards,
+		"erd_count_consensus":             status.CountConsensus,
+		"erd_count_leader":                status.CountLeader,
+		"erd_count_accepted_blocks":       status.CountAcceptedBlocks,
+		"erd_consensus_state":             status.ConsensusState,
+		"erd_tx_pool_load":                status.TxPoolLoad,
+	} {
+		metrics[key] = value
+	}
+
+	return json.Marshal(map[string]interface{}(metrics))
+}
+
+// NewNodeStatus - builds a node status from raw node metrics
+func NewNodeStatus(metrics Metrics) NodeStatus {
+	return NodeStatus{
+		ShardID:                  uint32(metrics.Uint64("erd_shard_id")),
+		EpochNumber:              uint32(metrics.Uint64("erd_epoch_number")),
+		CurrentRound:             metrics.Uint64("erd_current_round"),
+		SynchronizedRound:        metrics.Uint64("erd_synchronized_round"),
+		Nonce:                    metrics.Uint64("erd_nonce"),
+		ProbableHighestNonce:     metrics.Uint64("erd_probable_highest_nonce"),
+		NonceAtEpochStart:        metrics.Uint64("erd_nonce_at_epoch_start"),
+		RoundAtEpochStart:        metrics.Uint64("erd_round_at_epoch_start"),
+		RoundsPerEpoch:           metrics.Uint64("erd_rounds_per_epoch"),
+		RoundTime:                metrics.Uint64("erd_round_time"),
+		CrossCheckBlockHeight:    metrics.String("erd_cross_check_block_height"),
+		CurrentBlockHash:         metrics.String("erd_current_block_hash"),
+		IsSyncing:                metrics.Bool("erd_is_syncing"),
+		PeerType:                 metrics.String("erd_peer_type"),
+		NodeType:                 metrics.String("erd_node_type"),
+		NodeDisplayName:          metrics.String("erd_node_display_name"),
+		PublicKeyBlockSign:       metrics.String("erd_public_key_block_sign"),
+		AppVersion:               metrics.String("erd_app_version"),
+		LatestTagSoftwareVersion: metrics.String("erd_latest_tag_software_version"),
+		NumConnectedPeers:        metrics.Uint64("erd_num_connected_peers"),
+		ConnectedNodes:           metrics.Uint64("erd_connected_nodes"),
+		LiveValidatorNodes:       metrics.Uint64("erd_live_validator_nodes"),
+		NumValidators:            metrics.Uint64("erd_num_validators"),
+		NumShards:                uint32(metrics.Uint64("erd_num_shards_without_meta")),
+		CountConsensus:           metrics.Uint64("erd_count_consensus"),
+		CountLeader:              metrics.Uint64("erd_count_leader"),
+		CountAcceptedBlocks:      metrics.Uint64("erd_count_accepted_blocks"),
+		ConsensusState:           metrics.String("erd_consensus_state"),
+		TxPoolLoad:               metrics.Uint64("erd_tx_pool_load"),
+		Metrics:                  metrics,
+	}
+}
+
+// Uint64 - returns a metric as an unsigned integer, 0 if it's missing or can't be converted
+func (metrics Metrics) Uint64(key string) uint64 {
+	switch value := metrics[key].(type) {
+	case json.Number:
+		if converted, err := strconv.ParseUint(value.String(), 10, 64); err == nil {
+			return converted
+		}
+		if converted, err := value.Float64(); err == nil && converted > 0 {
+			return uint64(converted)
+		}
+	case float64:
+		if value > 0 {
+			return uint64(value)
+		}
+	case string:
+		if converted, err := strconv.ParseUint(strings.TrimSpace(value), 10, 64); err == nil {
+			return converted
+		}
+	case bool:
+		if value {
+			return 1
+		}
+	}
+
+	return 0
+}
+
+// String - returns a metric as a string, empty if it's missing
+func (metrics Metrics) String(key string) string {
+	switch value := metrics[key].(type) {
+	case nil:
+		return ""
+	case string:
+		return value
+	default:
+		return fmt.Sprintf("%v", value)
+	}
+}
+
+// Bool - returns a metric as a boolean, where non zero numbers and "true" are considered true
+func (metrics Metrics) Bool(key string) bool {
+	switch value := metrics[key].(type) {
+	case bool:
+		return value
+	case string:
+		converted, err := strconv.ParseBool(strings.TrimSpace(value))
+		if err == nil {
+			return converted
+		}
+		return metrics.Uint64(key) > 0
+	default:
+		return metrics.Uint64(key) > 0
+	}
+}
@@ -0,0 +1,113 @@
+package api_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/SebastianJ/elrond-sdk/api"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetNodeStatus(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"details":{"erd_shard_id":4294967295,"erd_nonce":"1234","erd_epoch_number":7,"erd_is_syncing":0,"erd_peer_type":"eligible","erd_num_connected_peers":42,"erd_app_version":"v1.0.133","erd_custom_metric":"custom"}}`))
+	}))
+	defer server.Close()
+
+	client := api.Client{Host: server.URL}
+	status, err := client.GetNodeStatus()
+
+	assert.Nil(t, err)
+	assert.Equal(t, uint32(4294967295), status.ShardID)
+	assert.Equal(t, uint64(1234), status.Nonce)
+	assert.Equal(t, uint32(7), status.EpochNumber)
+	assert.False(t, status.IsSyncing)
+	assert.Equal(t, "eligible", status.PeerType)
+	assert.Equal(t, uint64(42), status.NumConnectedPeers)
+	assert.Equal(t, "v1.0.133", status.AppVersion)
+	assert.Equal(t, "custom", status.Metrics.String("erd_custom_metric"))
+}
+
+func TestNodeStatusTolerantDecoding(t *testing.T) {
+	t.Parallel()
+
+	status := api.NewNodeStatus(api.Metrics{
+		"erd_is_syncing": "true",
+		"erd_nonce":      float64(10),
+		"erd_shard_id":   "1",
+	})
+
+	assert.True(t, status.IsSyncing)
+	assert.Equal(t, uint64(10), status.Nonce)
+	assert.Equal(t, uint32(1), status.ShardID)
+	assert.Equal(t, uint64(0), status.Metrics.Uint64("erd_missing"))
+}
+
+func TestGetNodeStatusDetailsError(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"details":["unexpected"],"metrics":{"erd_nonce":5}}`))
+	}))
+	defer server.Close()
+
+	// Malformed details aren't hidden by falling back to other fields
+	client := api.Client{Host: server.URL}
+	_, err := client.GetNodeStatus()
+	assert.NotNil(t, err)
+}
+
+func TestGetNodeStatusMetrics(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"data":{"metrics":{"erd_nonce":5,"erd_shard_id":1}},"code":"successful"}`))
+	}))
+	defer server.Close()
+
+	client := api.Client{Host: server.URL}
+	status, err := client.GetNodeStatus()
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(5), status.Nonce)
+	assert.Equal(t, uint32(1), status.ShardID)
+}
+
+func TestNodeStatusRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	status := api.NewNodeStatus(api.Metrics{
+		"erd_nonce":         "1234",
+		"erd_shard_id":      float64(2),
+		"erd_is_syncing":    "true",
+		"erd_peer_type":     "eligible",
+		"erd_custom_metric": "custom",
+	})
+	status.NumConnectedPeers = 42
+
+	encoded, err := json.Marshal(status)
+	assert.Nil(t, err)
+
+	var decoded api.NodeStatus
+	assert.Nil(t, json.Unmarshal(encoded, &decoded))
+
+	assert.Equal(t, uint64(1234), decoded.Nonce)
+	assert.Equal(t, uint32(2), decoded.ShardID)
+	assert.True(t, decoded.IsSyncing)
+	assert.Equal(t, "eligible", decoded.PeerType)
+	assert.Equal(t, uint64(42), decoded.NumConnectedPeers)
+	assert.Equal(t, "custom", decoded.Metrics.String("erd_custom_metric"))
+
+	decoded.Metrics, status.Metrics = nil, nil
+	assert.Equal(t, status, decoded)
+
+	// Statuses built without metrics are encoded from their fields
+	encoded, err = json.Marshal(api.NodeStatus{Nonce: 7, AppVersion: "v1.0.133"})
+	assert.Nil(t, err)
+	assert.Nil(t, json.Unmarshal(encoded, &decoded))
+	assert.Equal(t, uint64(7), decoded.Nonce)
+	assert.Equal(t, "v1.0.133", decoded.AppVersion)
+}
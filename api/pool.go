@@ -0,0 +1,373 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// RoundRobin - spreads requests evenly across all healthy endpoints
+	RoundRobin SelectionStrategy = iota
+	// LeastLatency - sends requests to the healthy endpoint with the lowest observed latency
+	LeastLatency
+)
+
+var (
+	// ErrNoEndpoints - a pool was created without any endpoints
+	ErrNoEndpoints = errors.New("no endpoints supplied")
+
+	// DefaultPoolOptions - default options used for endpoint pools
+	DefaultPoolOptions = PoolOptions{
+		Strategy:            RoundRobin,
+		HealthCheckInterval: 30 * time.Second,
+		HealthCheckTimeout:  5 * time.Second,
+		FailureThreshold:    1,
+		RecoveryAfter:       30 * time.Second,
+	}
+
+	latencySmoothing = 0.2
+)
+
+// SelectionStrategy - how a pool picks the endpoint to use for a request
+type SelectionStrategy int
+
+// PoolOptions - configures the behavior of an endpoint pool
+type PoolOptions struct {
+	Strategy SelectionStrategy
+	// HealthCheckInterval is how often Start and Client.StartHealthChecks check the endpoints, 0 disables background checks
+	HealthCheckInterval time.Duration
+	HealthCheckTimeout  time.Duration
+	// FailureThreshold is the number of consecutive failures after which an endpoint is marked unhealthy
+	FailureThreshold int
+	// RecoveryAfter is how long an unhealthy endpoint is skipped before it's tried again
+	RecoveryAfter time.Duration
+	// HTTPClient is used by pooled clients and Pool.CheckHealth, defaults to a new http.Client
+	HTTPClient *http.Client
+}
+
+// Endpoint - a snapshot of the state of a pooled endpoint
+type Endpoint struct {
+	URL         string
+	Healthy     bool
+	Latency     time.Duration
+	Failures    int
+	LastFailure time.Time
+	LastChecked time.Time
+}
+
+// Pool - a set of node or API endpoints that requests get routed across
+type Pool struct {
+	options   PoolOptions
+	mutex     sync.RWMutex
+	endpoints []*Endpoint
+	counter   uint64
+	stop      chan struct{}
+	stopOnce  sync.Once
+}
+
+// NewPool - creates a new pool for the given endpoints
+func NewPool(endpoints []string, options PoolOptions) (*Pool, error) {
+	if len(endpoints) == 0 {
+		return nil, ErrNoEndpoints
+	}
+
+	if options.FailureThreshold <= 0 {
+		options.FailureThreshold = DefaultPoolOptions.FailureThreshold
+	}
+
+	if options.HealthCheckTimeout <= 0 {
+		options.HealthCheckTimeout = DefaultPoolOptions.HealthCheckTimeout
+	}
+
+	if options.RecoveryAfter <= 0 {
+		options.RecoveryAfter = DefaultPoolOptions.RecoveryAfter
+	}
+
+	if options.HTTPClient == nil {
+		options.HTTPClient = &http.Client{}
+	}
+
+	pool := &Pool{
+		options: options,
+		stop:    make(chan struct{}),
+	}
+
+	for _, endpoint := range endpoints {
+		if _, err := url.Parse(endpoint); err != nil {
+			return nil, errors.Wrapf(err, "invalid endpoint %s", endpoint)
+		}
+
+		pool.endpoints = append(pool.endpoints, &Endpoint{
+			URL:     strings.TrimRight(endpoint, "/"),
+			Healthy: true,
+		})
+	}
+
+	return pool, nil
+}
+
+// NewPooledClient - creates a new client that routes its requests across the given endpoints
+func NewPooledClient(endpoints []string, options PoolOptions) (Client, error) {
+	pool, err := NewPool(endpoints, options)
+	if err != nil {
+		return Client{}, err
+	}
+
+	return Client{
+		Host:   pool.endpoints[0].URL,
+		Client: pool.options.HTTPClient,
+		Pool:   pool,
+	}, nil
+}
+
+// Select - picks the endpoint to use for the next request
+func (pool *Pool) Select() string {
+	pool.mutex.RLock()
+	defer pool.mutex.RUnlock()
+
+	candidates := pool.availableEndpoints()
+	if len(candidates) == 0 {
+		candidates = pool.endpoints
+	}
+
+	if pool.options.Strategy == LeastLatency {
+		selected := candidates[0]
+		for _, endpoint := range candidates[1:] {
+			if endpoint.Latency < selected.Latency {
+				selected = endpoint
+			}
+		}
+
+		return selected.URL
+	}
+
+	index := atomic.AddUint64(&pool.counter, 1) - 1
+
+	return candidates[index%uint64(len(candidates))].URL
+}
+
+// Endpoints - returns a snapshot of all endpoints in the pool
+func (pool *Pool) Endpoints() []Endpoint {
+	pool.mutex.RLock()
+	defer pool.mutex.RUnlock()
+
+	endpoints := make([]Endpoint, len(pool.endpoints))
+	for index, endpoint := range pool.endpoints {
+		endpoints[index] = *endpoint
+	}
+
+	return endpoints
+}
+
+// Size - returns the number of endpoints in the pool
+func (pool *Pool) Size() int {
+	return len(pool.endpoints)
+}
+
+// MarkSuccess - records a successful request against an endpoint
+func (pool *Pool) MarkSuccess(endpointURL string, latency time.Duration) {
+	pool.mutex.Lock()
+	defer pool.mutex.Unlock()
+
+	endpoint := pool.find(endpointURL)
+	if endpoint == nil {
+		return
+	}
+
+	endpoint.Healthy = true
+	endpoint.Failures = 0
+
+	if endpoint.Latency == 0 {
+		endpoint.Latency = latency
+	} else {
+		endpoint.Latency = time.Duration((1-latencySmoothing)*float64(endpoint.Latency) + latencySmoothing*float64(latency))
+	}
+}
+
+// MarkFailure - records a failed request against an endpoint
+func (pool *Pool) MarkFailure(endpointURL string) {
+	pool.mutex.Lock()
+	defer pool.mutex.Unlock()
+
+	endpoint := pool.find(endpointURL)
+	if endpoint == nil {
+		return
+	}
+
+	endpoint.Failures++
+	endpoint.LastFailure = time.Now()
+
+	if endpoint.Failures >= pool.options.FailureThreshold {
+		endpoint.Healthy = false
+	}
+}
+
+// CheckHealth - checks the health of every endpoint using its /node/status route
+// The checks use the pool's HTTPClient as is, use Client.CheckPoolHealth to apply a client's proxy, TLS settings and middleware
+func (pool *Pool) CheckHealth(ctx context.Context) {
+	pool.checkHealth(ctx, Client{Client: pool.options.HTTPClient})
+}
+
+// Start - runs the health checks in the background until Stop is called
+func (pool *Pool) Start() {
+	pool.start(pool.CheckHealth)
+}
+
+// CheckPoolHealth - checks the health of every pooled endpoint through the client, including its proxy, TLS settings and middleware
+func (client *Client) CheckPoolHealth(ctx context.Context) {
+	if client.Pool == nil {
+		return
+	}
+
+	client.Initialize()
+
+	checker := *client
+	checker.Pool = nil
+	checker.RetryPolicy = nil
+	checker.RateLimiter = nil
+	checker.Cache = nil
+
+	client.Pool.checkHealth(ctx, checker)
+}
+
+// StartHealthChecks - runs CheckPoolHealth in the background until the pool is stopped
+func (client *Client) StartHealthChecks() {
+	if client.Pool == nil {
+		return
+	}
+
+	client.Pool.start(client.CheckPoolHealth)
+}
+
+// checkHealth - checks the health of every endpoint using a copy of the checker pointed at the endpoint
+func (pool *Pool) checkHealth(ctx context.Context, checker Client) {
+	var wg sync.WaitGroup
+
+	for _, endpoint := range pool.Endpoints() {
+		wg.Add(1)
+
+		go func(endpointURL string, checker Client) {
+			defer wg.Done()
+
+			checkCtx, cancel := context.WithTimeout(ctx, pool.options.HealthCheckTimeout)
+			defer cancel()
+
+			checker.Host = endpointURL
+
+			start := time.Now()
+			_, err := checker.StatusContext(checkCtx)
+
+			pool.mutex.Lock()
+			if endpoint := pool.find(endpointURL); endpoint != nil {
+				endpoint.LastChecked = time.Now()
+			}
+			pool.mutex.Unlock()
+
+			if err != nil {
+				pool.MarkFailure(endpointURL)
+				return
+			}
+
+			pool.MarkSuccess(endpointURL, time.Since(start))
+		}(endpoint.URL, checker)
+	}
+
+	wg.Wait()
+}
+
+// start - runs the health check in the background until Stop is called
+func (pool *Pool) start(check func(ctx context.Context)) {
+	if pool.options.HealthCheckInterval <= 0 {
+		return
+	}
+
+	go func() {
+		ticker := time.NewTicker(pool.options.HealthCheckInterval)
+		defer ticker.Stop()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		check(ctx)
+
+		for {
+			select {
+			case <-pool.stop:
+				return
+			case <-ticker.C:
+				check(ctx)
+			}
+		}
+	}()
+}
+
+// Stop - stops the background health checks
+func (pool *Pool) Stop() {
+	pool.stopOnce.Do(func() {
+		close(pool.stop)
+	})
+}
+
+// endpointFor - finds the pooled endpoint a request URL was sent to
+func (pool *Pool) endpointFor(requestURL *url.URL) string {
+	pool.mutex.RLock()
+	defer pool.mutex.RUnlock()
+
+	raw := requestURL.String()
+
+	for _, endpoint := range pool.endpoints {
+		if !strings.HasPrefix(raw, endpoint.URL) {
+			continue
+		}
+
+		if remainder := raw[len(endpoint.URL):]; remainder == "" || remainder[0] == '/' || remainder[0] == '?' {
+			return endpoint.URL
+		}
+	}
+
+	return ""
+}
+
+// reroute - moves a request URL from its current endpoint to the next selected one
+func (pool *Pool) reroute(requestURL *url.URL) (*url.URL, error) {
+	current := pool.endpointFor(requestURL)
+	if current == "" {
+		return requestURL, nil
+	}
+
+	next := pool.Select()
+	if next == current {
+		return requestURL, nil
+	}
+
+	return url.Parse(next + strings.TrimPrefix(requestURL.String(), current))
+}
+
+func (pool *Pool) availableEndpoints() []*Endpoint {
+	available := []*Endpoint{}
+
+	for _, endpoint := range pool.endpoints {
+		if endpoint.Healthy || time.Since(endpoint.LastFailure) >= pool.options.RecoveryAfter {
+			available = append(available, endpoint)
+		}
+	}
+
+	return available
+}
+
+func (pool *Pool) find(endpointURL string) *Endpoint {
+	for _, endpoint := range pool.endpoints {
+		if endpoint.URL == endpointURL {
+			return endpoint
+		}
+	}
+
+	return nil
+}
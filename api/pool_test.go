@@ -0,0 +1,119 @@
+package api_test
+
+import (
+	"context"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/SebastianJ/elrond-sdk/api"
+	"github.com/stretchr/testify/assert"
+)
+
+func newStatusServer(status int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+		w.Write([]byte(`{"details":{"erd_nonce":10}}`))
+	}))
+}
+
+func TestPooledClientFailsOver(t *testing.T) {
+	t.Parallel()
+
+	failing := newStatusServer(http.StatusInternalServerError)
+	defer failing.Close()
+	healthy := newStatusServer(http.StatusOK)
+	defer healthy.Close()
+
+	client, err := api.NewPooledClient([]string{failing.URL, healthy.URL}, api.PoolOptions{})
+	assert.Nil(t, err)
+
+	for i := 0; i < 4; i++ {
+		_, err := client.Status()
+		assert.Nil(t, err)
+	}
+
+	endpoints := client.Pool.Endpoints()
+	assert.False(t, endpoints[0].Healthy)
+	assert.True(t, endpoints[1].Healthy)
+}
+
+func TestPoolRoundRobin(t *testing.T) {
+	t.Parallel()
+
+	pool, err := api.NewPool([]string{"http://first", "http://second/"}, api.PoolOptions{})
+	assert.Nil(t, err)
+
+	assert.Equal(t, "http://first", pool.Select())
+	assert.Equal(t, "http://second", pool.Select())
+	assert.Equal(t, "http://first", pool.Select())
+
+	pool.MarkFailure("http://first")
+	assert.Equal(t, "http://second", pool.Select())
+	assert.Equal(t, "http://second", pool.Select())
+}
+
+func TestPoolLeastLatency(t *testing.T) {
+	t.Parallel()
+
+	pool, err := api.NewPool([]string{"http://slow", "http://fast"}, api.PoolOptions{Strategy: api.LeastLatency})
+	assert.Nil(t, err)
+
+	pool.MarkSuccess("http://slow", 200*time.Millisecond)
+	pool.MarkSuccess("http://fast", 20*time.Millisecond)
+
+	assert.Equal(t, "http://fast", pool.Select())
+}
+
+func TestPoolHealthChecks(t *testing.T) {
+	t.Parallel()
+
+	failing := newStatusServer(http.StatusServiceUnavailable)
+	defer failing.Close()
+	healthy := newStatusServer(http.StatusOK)
+	defer healthy.Close()
+
+	pool, err := api.NewPool([]string{failing.URL, healthy.URL}, api.PoolOptions{})
+	assert.Nil(t, err)
+
+	pool.CheckHealth(context.Background())
+
+	endpoints := pool.Endpoints()
+	assert.False(t, endpoints[0].Healthy)
+	assert.True(t, endpoints[1].Healthy)
+	assert.False(t, endpoints[1].LastChecked.IsZero())
+}
+
+func TestClientPoolHealthChecks(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Write([]byte(`{"details":{"erd_nonce":10}}`))
+	}))
+	defer server.Close()
+
+	client, err := api.NewPooledClient([]string{server.URL}, api.PoolOptions{FailureThreshold: 1})
+	assert.Nil(t, err)
+	client.TLS = &api.TLSConfig{CAPEM: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})}
+	client.Middleware = []api.Middleware{api.WithBearerToken("token")}
+
+	// The pool on its own knows neither the CA nor the credentials
+	client.Pool.CheckHealth(context.Background())
+	assert.False(t, client.Pool.Endpoints()[0].Healthy)
+
+	client.CheckPoolHealth(context.Background())
+	assert.True(t, client.Pool.Endpoints()[0].Healthy)
+}
+
+func TestNewPoolWithoutEndpoints(t *testing.T) {
+	t.Parallel()
+
+	_, err := api.NewPool(nil, api.PoolOptions{})
+	assert.Equal(t, api.ErrNoEndpoints, err)
+}
@@ -0,0 +1,185 @@
+package api
+
+import (
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// Names of the built-in network profiles
+const (
+	Mainnet = "mainnet"
+	Testnet = "testnet"
+	Devnet  = "devnet"
+	Local   = "local"
+)
+
+var (
+	// ErrUnknownNetwork - no network profile is registered under the requested name
+	ErrUnknownNetwork = errors.New("unknown network")
+
+	// ErrInvalidNetworkProfile - the network profile is missing its name or endpoints
+	ErrInvalidNetworkProfile = errors.New("invalid network profile")
+
+	networkProfilesMutex sync.RWMutex
+	networkProfiles      = map[string]NetworkProfile{
+		Mainnet: {
+			Name:           Mainnet,
+			Endpoints:      []string{"https://wallet-api.elrond.com", "https://api.elrond.com"},
+			ChainID:        "1",
+			HRP:            "erd",
+			NumShards:      3,
+			GasPrice:       1000000000,
+			GasLimit:       50000,
+			GasPerDataByte: 1500,
+			ExplorerURL:    "https://explorer.elrond.com",
+		},
+		Testnet: {
+			Name:           Testnet,
+			Endpoints:      []string{"https://testnet-api.elrond.com"},
+			ChainID:        "T",
+			HRP:            "erd",
+			NumShards:      3,
+			GasPrice:       1000000000,
+			GasLimit:       50000,
+			GasPerDataByte: 1500,
+			ExplorerURL:    "https://testnet-explorer.elrond.com",
+		},
+		Devnet: {
+			Name:           Devnet,
+			Endpoints:      []string{"https://devnet-api.elrond.com"},
+			ChainID:        "D",
+			HRP:            "erd",
+			NumShards:      3,
+			GasPrice:       1000000000,
+			GasLimit:       50000,
+			GasPerDataByte: 1500,
+			ExplorerURL:    "https://devnet-explorer.elrond.com",
+		},
+		Local: {
+			Name:           Local,
+			Endpoints:      []string{"http://localhost:7950"},
+			ChainID:        "local-testnet",
+			HRP:            "erd",
+			NumShards:      1,
+			GasPrice:       1000000000,
+			GasLimit:       50000,
+			GasPerDataByte: 1500,
+		},
+	}
+)
+
+// NetworkProfile - bundles the settings needed to talk to a specific network
+type NetworkProfile struct {
+	Name      string
+	Endpoints []string
+	ChainID   string
+	// HRP - the human readable part of the network's bech32 addresses
+	HRP string
+	// NumShards - the number of shards, excluding the metachain
+	NumShards uint32
+	// GasPrice, GasLimit and GasPerDataByte are the network's minimum gas parameters,
+	// the built-in networks accept a lower gas price than the more conservative DefaultGasParams of the transactions package
+	GasPrice       uint64
+	GasLimit       uint64
+	GasPerDataByte uint64
+	ExplorerURL    string
+}
+
+// RegisterNetwork - registers a network profile, replacing any profile previously registered under the same name
+func RegisterNetwork(profile NetworkProfile) error {
+	if profile.Name == "" || len(profile.Endpoints) == 0 {
+		return ErrInvalidNetworkProfile
+	}
+
+	for _, endpoint := range profile.Endpoints {
+		if _, err := url.ParseRequestURI(endpoint); err != nil {
+			return errors.Wrapf(ErrInvalidNetworkProfile, "invalid endpoint %s", endpoint)
+		}
+	}
+
+	networkProfilesMutex.Lock()
+	defer networkProfilesMutex.Unlock()
+
+	profile.Endpoints = append([]string{}, profile.Endpoints...)
+	networkProfiles[strings.ToLower(profile.Name)] = profile
+
+	return nil
+}
+
+// LookupNetwork - returns the network profile registered under the supplied name
+func LookupNetwork(name string) (NetworkProfile, error) {
+	networkProfilesMutex.RLock()
+	defer networkProfilesMutex.RUnlock()
+
+	profile, ok := networkProfiles[strings.ToLower(name)]
+	if !ok {
+		return NetworkProfile{}, errors.Wrapf(ErrUnknownNetwork, "%q", name)
+	}
+
+	profile.Endpoints = append([]string{}, profile.Endpoints...)
+
+	return profile, nil
+}
+
+// Networks - the names of all registered network profiles
+func Networks() []string {
+	networkProfilesMutex.RLock()
+	defer networkProfilesMutex.RUnlock()
+
+	names := make([]string, 0, len(networkProfiles))
+	for name := range networkProfiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// NewNetworkClient - creates a client for the network profile registered under the supplied name
+func NewNetworkClient(name string) (Client, error) {
+	profile, err := LookupNetwork(name)
+	if err != nil {
+		return Client{}, err
+	}
+
+	return Client{Host: profile.Endpoints[0], Network: &profile}, nil
+}
+
+// ExplorerTransactionURL - the explorer page of a transaction, empty if the network has no explorer
+func (profile NetworkProfile) ExplorerTransactionURL(hash string) string {
+	if profile.ExplorerURL == "" {
+		return ""
+	}
+
+	return strings.TrimRight(profile.ExplorerURL, "/") + "/transactions/" + hash
+}
+
+// ExplorerAddressURL - the explorer page of an address, empty if the network has no explorer
+func (profile NetworkProfile) ExplorerAddressURL(address string) string {
+	if profile.ExplorerURL == "" {
+		return ""
+	}
+
+	return strings.TrimRight(profile.ExplorerURL, "/") + "/address/" + address
+}
+
+// hasEndpoint - check if the host points to one of the profile's endpoints
+func (profile NetworkProfile) hasEndpoint(host string) bool {
+	hostURL, err := url.Parse(host)
+	if err != nil || hostURL.Host == "" {
+		return false
+	}
+
+	for _, endpoint := range profile.Endpoints {
+		endpointURL, err := url.Parse(endpoint)
+		if err == nil && strings.EqualFold(endpointURL.Host, hostURL.Host) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,78 @@
+package api_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/SebastianJ/elrond-sdk/api"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLookupNetwork(t *testing.T) {
+	t.Parallel()
+
+	profile, err := api.LookupNetwork("Mainnet")
+	assert.Nil(t, err)
+	assert.Equal(t, "1", profile.ChainID)
+	assert.Equal(t, "erd", profile.HRP)
+	assert.Equal(t, uint32(3), profile.NumShards)
+	assert.Equal(t, uint64(1000000000), profile.GasPrice)
+	assert.Equal(t, "https://explorer.elrond.com/transactions/abc", profile.ExplorerTransactionURL("abc"))
+
+	// Profiles are returned as copies
+	profile.Endpoints[0] = "http://modified"
+	profile, _ = api.LookupNetwork(api.Mainnet)
+	assert.Equal(t, "https://wallet-api.elrond.com", profile.Endpoints[0])
+
+	_, err = api.LookupNetwork("unknown")
+	assert.True(t, errors.Is(err, api.ErrUnknownNetwork))
+
+	for _, name := range []string{api.Mainnet, api.Testnet, api.Devnet, api.Local} {
+		assert.Contains(t, api.Networks(), name)
+
+		profile, err := api.LookupNetwork(name)
+		assert.Nil(t, err)
+		assert.NotEmpty(t, profile.HRP, name)
+		assert.NotZero(t, profile.NumShards, name)
+		assert.NotZero(t, profile.GasPrice, name)
+		assert.NotZero(t, profile.GasLimit, name)
+		assert.NotZero(t, profile.GasPerDataByte, name)
+	}
+}
+
+func TestRegisterNetwork(t *testing.T) {
+	t.Parallel()
+
+	err := api.RegisterNetwork(api.NetworkProfile{Name: "private"})
+	assert.True(t, errors.Is(err, api.ErrInvalidNetworkProfile))
+
+	err = api.RegisterNetwork(api.NetworkProfile{
+		Name:      "private",
+		Endpoints: []string{"https://gateway.private.example"},
+		ChainID:   "P",
+	})
+	assert.Nil(t, err)
+
+	client, err := api.NewNetworkClient("private")
+	assert.Nil(t, err)
+	assert.Equal(t, "https://gateway.private.example", client.Host)
+	assert.Equal(t, "P", client.Network.ChainID)
+	assert.False(t, client.UsingOfficialAPI())
+	assert.Empty(t, client.Network.ExplorerAddressURL("erd1test"))
+}
+
+func TestUsingOfficialAPI(t *testing.T) {
+	t.Parallel()
+
+	for host, official := range map[string]bool{
+		"https://wallet-api.elrond.com":   true,
+		"http://api.elrond.com/":          true,
+		"https://testnet-api.elrond.com":  false,
+		"http://localhost:7950":           false,
+		"https://api.elrond.com.evil.com": false,
+		"https://my-node.example:8080":    false,
+	} {
+		client := api.Client{Host: host}
+		assert.Equal(t, official, client.UsingOfficialAPI(), host)
+	}
+}
@@ -0,0 +1,212 @@
+package api
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultLatencyBuckets - the upper bounds in seconds of the request latency histogram buckets
+var DefaultLatencyBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// PrometheusMetrics - a metrics collector keeping its own registry, exposed in the Prometheus text format
+type PrometheusMetrics struct {
+	namespace string
+	buckets   []float64
+
+	mutex        sync.Mutex
+	requests     map[string]float64
+	errors       map[string]float64
+	inFlight     map[string]float64
+	transactions map[string]float64
+	latencies    map[string]*histogram
+}
+
+type histogram struct {
+	labels string
+	counts []uint64
+	count  uint64
+	sum    float64
+}
+
+// NewPrometheusMetrics - creates a collector prefixing its metrics with the namespace, elrond_sdk if empty
+// Latency buckets default to DefaultLatencyBuckets if none are supplied
+func NewPrometheusMetrics(namespace string, buckets ...float64) *PrometheusMetrics {
+	if namespace == "" {
+		namespace = "elrond_sdk"
+	}
+
+	if len(buckets) == 0 {
+		buckets = DefaultLatencyBuckets
+	}
+	buckets = append([]float64{}, buckets...)
+	sort.Float64s(buckets)
+
+	return &PrometheusMetrics{
+		namespace:    namespace,
+		buckets:      buckets,
+		requests:     make(map[string]float64),
+		errors:       make(map[string]float64),
+		inFlight:     make(map[string]float64),
+		transactions: make(map[string]float64),
+		latencies:    make(map[string]*histogram),
+	}
+}
+
+// RequestStarted - increments the in-flight gauge of the endpoint
+func (metrics *PrometheusMetrics) RequestStarted(method string, endpoint string) {
+	metrics.mutex.Lock()
+	defer metrics.mutex.Unlock()
+
+	metrics.inFlight[formatLabels("method", method, "endpoint", endpoint)]++
+}
+
+// RequestFinished - records the request count, error count and latency of the endpoint
+func (metrics *PrometheusMetrics) RequestFinished(method string, endpoint string, statusCode int, errorType string, duration time.Duration) {
+	metrics.mutex.Lock()
+	defer metrics.mutex.Unlock()
+
+	endpointLabels := formatLabels("method", method, "endpoint", endpoint)
+	metrics.inFlight[endpointLabels]--
+	metrics.requests[formatLabels("method", method, "endpoint", endpoint, "code", strconv.Itoa(statusCode))]++
+
+	if errorType != "" {
+		metrics.errors[formatLabels("method", method, "endpoint", endpoint, "type", errorType)]++
+	}
+
+	latency, ok := metrics.latencies[endpointLabels]
+	if !ok {
+		latency = &histogram{labels: endpointLabels, counts: make([]uint64, len(metrics.buckets))}
+		metrics.latencies[endpointLabels] = latency
+	}
+
+	seconds := duration.Seconds()
+	for index, bound := range metrics.buckets {
+		if seconds <= bound {
+			latency.counts[index]++
+		}
+	}
+	latency.count++
+	latency.sum += seconds
+}
+
+// TransactionSubmitted - counts the transaction outcome
+func (metrics *PrometheusMetrics) TransactionSubmitted(outcome string) {
+	metrics.mutex.Lock()
+	defer metrics.mutex.Unlock()
+
+	metrics.transactions[formatLabels("outcome", outcome)]++
+}
+
+// WriteTo - writes all metrics in the Prometheus text exposition format
+func (metrics *PrometheusMetrics) WriteTo(w io.Writer) (int64, error) {
+	metrics.mutex.Lock()
+	defer metrics.mutex.Unlock()
+
+	writer := &countingWriter{writer: bufio.NewWriter(w)}
+
+	metrics.writeSamples(writer, "requests_total", "counter", "Requests sent, by endpoint and status code.", metrics.requests)
+	metrics.writeSamples(writer, "request_errors_total", "counter", "Failed requests, by endpoint and error type.", metrics.errors)
+	metrics.writeSamples(writer, "requests_in_flight", "gauge", "Requests currently in flight, by endpoint.", metrics.inFlight)
+	metrics.writeSamples(writer, "transactions_total", "counter", "Submitted transactions, by outcome.", metrics.transactions)
+	metrics.writeHistograms(writer)
+
+	if writer.err == nil {
+		writer.err = writer.writer.Flush()
+	}
+
+	return writer.written, writer.err
+}
+
+// ServeHTTP - exposes the metrics, so the collector can be mounted as a /metrics handler
+func (metrics *PrometheusMetrics) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	metrics.WriteTo(w)
+}
+
+func (metrics *PrometheusMetrics) writeSamples(writer *countingWriter, name string, kind string, help string, samples map[string]float64) {
+	name = metrics.namespace + "_" + name
+	writer.printf("# HELP %s %s\n# TYPE %s %s\n", name, help, name, kind)
+
+	for _, labels := range sortedKeys(samples) {
+		writer.printf("%s{%s} %s\n", name, labels, formatValue(samples[labels]))
+	}
+}
+
+func (metrics *PrometheusMetrics) writeHistograms(writer *countingWriter) {
+	name := metrics.namespace + "_request_duration_seconds"
+	writer.printf("# HELP %s Request latency in seconds, by endpoint.\n# TYPE %s histogram\n", name, name)
+
+	labels := make([]string, 0, len(metrics.latencies))
+	for key := range metrics.latencies {
+		labels = append(labels, key)
+	}
+	sort.Strings(labels)
+
+	for _, key := range labels {
+		latency := metrics.latencies[key]
+		for index, bound := range metrics.buckets {
+			writer.printf("%s_bucket{%s,le=\"%s\"} %d\n", name, latency.labels, formatValue(bound), latency.counts[index])
+		}
+		writer.printf("%s_bucket{%s,le=\"+Inf\"} %d\n", name, latency.labels, latency.count)
+		writer.printf("%s_sum{%s} %s\n", name, latency.labels, formatValue(latency.sum))
+		writer.printf("%s_count{%s} %d\n", name, latency.labels, latency.count)
+	}
+}
+
+// formatLabels - formats label name/value pairs, escaping the values as required by the text format
+func formatLabels(pairs ...string) string {
+	formatted := make([]string, 0, len(pairs)/2)
+	escaper := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+	for index := 0; index+1 < len(pairs); index += 2 {
+		formatted = append(formatted, fmt.Sprintf(`%s="%s"`, pairs[index], escaper.Replace(pairs[index+1])))
+	}
+
+	return strings.Join(formatted, ",")
+}
+
+func formatValue(value float64) string {
+	switch {
+	case math.IsInf(value, 1):
+		return "+Inf"
+	case math.IsInf(value, -1):
+		return "-Inf"
+	}
+
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
+
+func sortedKeys(samples map[string]float64) []string {
+	keys := make([]string, 0, len(samples))
+	for key := range samples {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+// countingWriter - keeps track of the bytes written and the first error
+type countingWriter struct {
+	writer  *bufio.Writer
+	written int64
+	err     error
+}
+
+func (writer *countingWriter) printf(format string, args ...interface{}) {
+	if writer.err != nil {
+		return
+	}
+
+	written, err := fmt.Fprintf(writer.writer, format, args...)
+	writer.written += int64(written)
+	writer.err = err
+}
@@ -0,0 +1,178 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// ReadRequests - account, status and other lookups
+	ReadRequests EndpointClass = iota
+	// TransactionRequests - transaction submissions
+	TransactionRequests
+)
+
+// EndpointClass - groups requests that share the same rate and concurrency limits
+type EndpointClass int
+
+// Limit - rate and concurrency limits for a class of requests
+type Limit struct {
+	// RequestsPerSecond is the token bucket refill rate, 0 disables rate limiting
+	RequestsPerSecond float64
+	// Burst is the token bucket size, defaults to 1
+	Burst int
+	// MaxInFlight caps the number of concurrent requests, 0 disables the cap
+	MaxInFlight int
+}
+
+// RateLimiter - client-side token bucket rate limits and concurrency caps per endpoint class
+type RateLimiter struct {
+	buckets map[EndpointClass]*bucket
+}
+
+type bucket struct {
+	limit        Limit
+	mutex        sync.Mutex
+	tokens       float64
+	lastRefill   time.Time
+	blockedUntil time.Time
+	inFlight     chan struct{}
+}
+
+// NewRateLimiter - creates a new rate limiter using the supplied limits
+func NewRateLimiter(limits map[EndpointClass]Limit) *RateLimiter {
+	limiter := &RateLimiter{
+		buckets: make(map[EndpointClass]*bucket),
+	}
+
+	for class, limit := range limits {
+		if limit.Burst <= 0 {
+			limit.Burst = 1
+		}
+
+		classBucket := &bucket{
+			limit:      limit,
+			tokens:     float64(limit.Burst),
+			lastRefill: time.Now(),
+		}
+
+		if limit.MaxInFlight > 0 {
+			classBucket.inFlight = make(chan struct{}, limit.MaxInFlight)
+		}
+
+		limiter.buckets[class] = classBucket
+	}
+
+	return limiter
+}
+
+// Acquire - blocks until a request of the given class is allowed to be sent
+// The returned release function has to be called once the request has completed
+func (limiter *RateLimiter) Acquire(ctx context.Context, class EndpointClass) (func(), error) {
+	classBucket, ok := limiter.buckets[class]
+	if !ok {
+		return func() {}, nil
+	}
+
+	release := func() {}
+
+	if classBucket.inFlight != nil {
+		select {
+		case classBucket.inFlight <- struct{}{}:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+
+		release = func() {
+			<-classBucket.inFlight
+		}
+	}
+
+	for {
+		wait := classBucket.reserve()
+		if wait <= 0 {
+			return release, nil
+		}
+
+		if err := sleepContext(ctx, wait); err != nil {
+			release()
+			return nil, err
+		}
+	}
+}
+
+// Defer - holds back all requests of the given class for the supplied duration, e.g. as instructed by a Retry-After header
+func (limiter *RateLimiter) Defer(class EndpointClass, duration time.Duration) {
+	classBucket, ok := limiter.buckets[class]
+	if !ok || duration <= 0 {
+		return
+	}
+
+	classBucket.mutex.Lock()
+	defer classBucket.mutex.Unlock()
+
+	if until := time.Now().Add(duration); until.After(classBucket.blockedUntil) {
+		classBucket.blockedUntil = until
+	}
+}
+
+// reserve - takes a token if one is available, otherwise returns how long to wait before trying again
+func (classBucket *bucket) reserve() time.Duration {
+	classBucket.mutex.Lock()
+	defer classBucket.mutex.Unlock()
+
+	now := time.Now()
+	if now.Before(classBucket.blockedUntil) {
+		return classBucket.blockedUntil.Sub(now)
+	}
+
+	rate := classBucket.limit.RequestsPerSecond
+	if rate <= 0 {
+		return 0
+	}
+
+	elapsed := now.Sub(classBucket.lastRefill).Seconds()
+	classBucket.tokens += elapsed * rate
+	if burst := float64(classBucket.limit.Burst); classBucket.tokens > burst {
+		classBucket.tokens = burst
+	}
+	classBucket.lastRefill = now
+
+	if classBucket.tokens >= 1 {
+		classBucket.tokens--
+		return 0
+	}
+
+	return time.Duration((1 - classBucket.tokens) / rate * float64(time.Second))
+}
+
+// classifyRequest - determines the endpoint class of a request
+func classifyRequest(request *http.Request) EndpointClass {
+	if request.Method == http.MethodPost && strings.Contains(request.URL.Path, "/transaction/send") {
+		return TransactionRequests
+	}
+
+	return ReadRequests
+}
+
+// parseRetryAfter - parses a Retry-After header given either in seconds or as an HTTP date
+func parseRetryAfter(header string) time.Duration {
+	header = strings.TrimSpace(header)
+	if header == "" {
+		return 0
+	}
+
+	if seconds, err := strconv.Atoi(header); err == nil {
+		return time.Duration(seconds) * time.Second
+	}
+
+	if date, err := http.ParseTime(header); err == nil {
+		return time.Until(date)
+	}
+
+	return 0
+}
@@ -0,0 +1,110 @@
+package api_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/SebastianJ/elrond-sdk/api"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimiterTokenBucket(t *testing.T) {
+	t.Parallel()
+
+	limiter := api.NewRateLimiter(map[api.EndpointClass]api.Limit{
+		api.ReadRequests: {RequestsPerSecond: 20, Burst: 2},
+	})
+
+	start := time.Now()
+	for i := 0; i < 4; i++ {
+		release, err := limiter.Acquire(context.Background(), api.ReadRequests)
+		assert.Nil(t, err)
+		release()
+	}
+
+	assert.True(t, time.Since(start) >= 90*time.Millisecond)
+
+	// Classes without limits are never held back
+	start = time.Now()
+	for i := 0; i < 10; i++ {
+		release, err := limiter.Acquire(context.Background(), api.TransactionRequests)
+		assert.Nil(t, err)
+		release()
+	}
+	assert.True(t, time.Since(start) < 50*time.Millisecond)
+}
+
+func TestRateLimiterMaxInFlight(t *testing.T) {
+	t.Parallel()
+
+	var inFlight, maxInFlight int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		current := atomic.AddInt32(&inFlight, 1)
+		for {
+			observed := atomic.LoadInt32(&maxInFlight)
+			if current <= observed || atomic.CompareAndSwapInt32(&maxInFlight, observed, current) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		atomic.AddInt32(&inFlight, -1)
+		w.Write([]byte(`{"account":{"nonce":1}}`))
+	}))
+	defer server.Close()
+
+	client := api.Client{
+		Host: server.URL,
+		RateLimiter: api.NewRateLimiter(map[api.EndpointClass]api.Limit{
+			api.ReadRequests: {MaxInFlight: 2},
+		}),
+	}
+	client.Initialize()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := client.GetAccount("erd1test")
+			assert.Nil(t, err)
+		}()
+	}
+	wg.Wait()
+
+	assert.True(t, atomic.LoadInt32(&maxInFlight) <= 2)
+}
+
+func TestRateLimiterHonorsRetryAfter(t *testing.T) {
+	t.Parallel()
+
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1) == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Write([]byte(`{"account":{"nonce":1}}`))
+	}))
+	defer server.Close()
+
+	client := api.Client{
+		Host: server.URL,
+		RateLimiter: api.NewRateLimiter(map[api.EndpointClass]api.Limit{
+			api.ReadRequests: {RequestsPerSecond: 100, Burst: 10},
+		}),
+	}
+
+	start := time.Now()
+	_, err := client.GetAccount("erd1test")
+	assert.NotNil(t, err)
+
+	_, err = client.GetAccount("erd1test")
+	assert.Nil(t, err)
+	assert.True(t, time.Since(start) >= 900*time.Millisecond)
+}
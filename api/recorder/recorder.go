@@ -0,0 +1,321 @@
+// Package recorder captures HTTP interactions of the api package into fixture files and replays them deterministically
+//
+// Both Recorder and Replayer are http.RoundTrippers and are plugged into an api.Client through its http.Client:
+//
+//	client := api.Client{Host: host, Client: &http.Client{Transport: replayer}}
+package recorder
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/SebastianJ/elrond-sdk/logging"
+	"github.com/pkg/errors"
+)
+
+// ErrNoMatchingInteraction - the replayed fixture doesn't contain a matching, unused interaction
+var ErrNoMatchingInteraction = errors.New("no matching interaction in fixture")
+
+// Request - a recorded request, matched on method, path (including the query) and body
+type Request struct {
+	Method string `json:"method"`
+	Path   string `json:"path"`
+	Body   string `json:"body,omitempty"`
+}
+
+// Response - a recorded response
+type Response struct {
+	StatusCode int         `json:"statusCode"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body"`
+}
+
+// Interaction - a recorded request/response pair
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+// Fixture - an ordered list of recorded interactions
+type Fixture struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// LoadFixture - reads a fixture file
+func LoadFixture(path string) (*Fixture, error) {
+	contents, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	fixture := &Fixture{}
+	if err := json.Unmarshal(contents, fixture); err != nil {
+		return nil, errors.Wrapf(err, "JSON Unmarshal")
+	}
+
+	return fixture, nil
+}
+
+// Save - writes the fixture to a file
+func (fixture *Fixture) Save(path string) error {
+	contents, err := json.MarshalIndent(fixture, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(path, append(contents, '\n'), 0644)
+}
+
+// Recorder - forwards requests to the underlying transport and records every interaction
+type Recorder struct {
+	Transport http.RoundTripper
+	// Redact - called with every interaction before it's recorded, defaults to DefaultRedact
+	// Replayers match requests against the recorded path as is or with DefaultRedact's redaction applied
+	Redact func(interaction *Interaction)
+
+	mutex   sync.Mutex
+	fixture Fixture
+}
+
+// NewRecorder - creates a recorder forwarding requests to the supplied transport, or http.DefaultTransport if nil
+func NewRecorder(transport http.RoundTripper) *Recorder {
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	return &Recorder{Transport: transport}
+}
+
+// RoundTrip - performs the request and records it
+func (recorder *Recorder) RoundTrip(request *http.Request) (*http.Response, error) {
+	requestBody, err := readRequestBody(request)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := recorder.Transport.RoundTrip(request)
+	if err != nil {
+		return nil, err
+	}
+
+	responseBody, err := readResponseBody(response)
+	if err != nil {
+		return nil, err
+	}
+
+	interaction := Interaction{
+		Request: Request{
+			Method: request.Method,
+			Path:   request.URL.RequestURI(),
+			Body:   string(requestBody),
+		},
+		Response: Response{
+			StatusCode: response.StatusCode,
+			Header:     response.Header.Clone(),
+			Body:       string(responseBody),
+		},
+	}
+
+	redact := recorder.Redact
+	if redact == nil {
+		redact = DefaultRedact
+	}
+	redact(&interaction)
+
+	recorder.mutex.Lock()
+	recorder.fixture.Interactions = append(recorder.fixture.Interactions, interaction)
+	recorder.mutex.Unlock()
+
+	return response, nil
+}
+
+// DefaultRedact - redacts sensitive query parameters and response headers, e.g. api keys and cookies
+func DefaultRedact(interaction *Interaction) {
+	interaction.Request.Path = logging.RedactURL(interaction.Request.Path)
+
+	for key := range interaction.Response.Header {
+		if logging.IsSensitive(key) {
+			interaction.Response.Header[key] = []string{logging.Redacted}
+		}
+	}
+}
+
+// Fixture - a copy of the interactions recorded so far
+func (recorder *Recorder) Fixture() *Fixture {
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+
+	return &Fixture{Interactions: append([]Interaction{}, recorder.fixture.Interactions...)}
+}
+
+// Save - writes the interactions recorded so far to a fixture file
+func (recorder *Recorder) Save(path string) error {
+	return recorder.Fixture().Save(path)
+}
+
+// Replayer - serves recorded responses without touching the network
+// Interactions are served in recorded order, so repeated identical requests (e.g. polling) replay their recorded sequence
+type Replayer struct {
+	// AllowRepeats - keep serving the last matching interaction once all matching interactions have been used
+	AllowRepeats bool
+
+	mutex   sync.Mutex
+	fixture *Fixture
+	used    []bool
+}
+
+// NewReplayer - creates a replayer serving the supplied fixture
+func NewReplayer(fixture *Fixture) *Replayer {
+	return &Replayer{
+		fixture: fixture,
+		used:    make([]bool, len(fixture.Interactions)),
+	}
+}
+
+// LoadReplayer - creates a replayer serving a fixture file
+func LoadReplayer(path string) (*Replayer, error) {
+	fixture, err := LoadFixture(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewReplayer(fixture), nil
+}
+
+// RoundTrip - serves the first unused interaction matching the request
+func (replayer *Replayer) RoundTrip(request *http.Request) (*http.Response, error) {
+	body, err := readRequestBody(request)
+	if err != nil {
+		return nil, err
+	}
+
+	replayer.mutex.Lock()
+	defer replayer.mutex.Unlock()
+
+	last := -1
+	for index, interaction := range replayer.fixture.Interactions {
+		if !interaction.Request.matches(request.Method, request.URL.RequestURI(), body) {
+			continue
+		}
+
+		if !replayer.used[index] {
+			replayer.used[index] = true
+			return interaction.Response.build(request), nil
+		}
+		last = index
+	}
+
+	if replayer.AllowRepeats && last >= 0 {
+		return replayer.fixture.Interactions[last].Response.build(request), nil
+	}
+
+	return nil, errors.Wrapf(ErrNoMatchingInteraction, "%s %s", request.Method, request.URL.RequestURI())
+}
+
+// Remaining - the number of interactions that haven't been replayed yet
+func (replayer *Replayer) Remaining() int {
+	replayer.mutex.Lock()
+	defer replayer.mutex.Unlock()
+
+	remaining := 0
+	for _, used := range replayer.used {
+		if !used {
+			remaining++
+		}
+	}
+
+	return remaining
+}
+
+func (recorded Request) matches(method string, path string, body []byte) bool {
+	if recorded.Path != path && recorded.Path != logging.RedactURL(path) {
+		return false
+	}
+
+	return recorded.Method == method && equalBodies([]byte(recorded.Body), body)
+}
+
+func (recorded Response) build(request *http.Request) *http.Response {
+	header := recorded.Header.Clone()
+	if header == nil {
+		header = http.Header{}
+	}
+
+	return &http.Response{
+		Status:        http.StatusText(recorded.StatusCode),
+		StatusCode:    recorded.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          ioutil.NopCloser(bytes.NewReader([]byte(recorded.Body))),
+		ContentLength: int64(len(recorded.Body)),
+		Request:       request,
+	}
+}
+
+// equalBodies - compares JSON bodies structurally, so key order and whitespace don't affect matching
+func equalBodies(recorded []byte, actual []byte) bool {
+	if bytes.Equal(recorded, actual) {
+		return true
+	}
+
+	var recordedValue, actualValue interface{}
+	if json.Unmarshal(recorded, &recordedValue) != nil || json.Unmarshal(actual, &actualValue) != nil {
+		return false
+	}
+
+	recordedNormalized, _ := json.Marshal(recordedValue)
+	actualNormalized, _ := json.Marshal(actualValue)
+
+	return bytes.Equal(recordedNormalized, actualNormalized)
+}
+
+// readResponseBody - reads and decompresses the response body, so fixtures stay readable and replay without the encoding
+func readResponseBody(response *http.Response) ([]byte, error) {
+	defer response.Body.Close()
+
+	var reader io.Reader = response.Body
+	if strings.EqualFold(response.Header.Get("Content-Encoding"), "gzip") {
+		gzipReader, err := gzip.NewReader(response.Body)
+		if err != nil {
+			return nil, err
+		}
+		reader = gzipReader
+
+		response.Header.Del("Content-Encoding")
+		response.Header.Del("Content-Length")
+	}
+
+	body, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	response.Body = ioutil.NopCloser(bytes.NewReader(body))
+	response.ContentLength = int64(len(body))
+
+	return body, nil
+}
+
+// readRequestBody - reads the request body and restores it so it can be sent afterwards
+func readRequestBody(request *http.Request) ([]byte, error) {
+	if request.Body == nil || request.Body == http.NoBody {
+		return nil, nil
+	}
+
+	body, err := ioutil.ReadAll(request.Body)
+	request.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	request.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	return body, nil
+}
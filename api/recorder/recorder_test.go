@@ -0,0 +1,191 @@
+package recorder_test
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/SebastianJ/elrond-sdk/api"
+	"github.com/SebastianJ/elrond-sdk/api/recorder"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecordAndReplay(t *testing.T) {
+	t.Parallel()
+
+	var nonce int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/address/erd1sender":
+			nonce++
+			w.Write([]byte(`{"account":{"address":"erd1sender","nonce":` + strconv.Itoa(nonce) + `,"balance":"1000"}}`))
+		case "/transaction/send":
+			w.Write([]byte(`{"txHash":"abcd"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+
+	rec := recorder.NewRecorder(nil)
+	client := api.Client{Host: server.URL, Client: &http.Client{Transport: rec}}
+
+	first, err := client.GetAccount("erd1sender")
+	assert.Nil(t, err)
+	second, err := client.GetAccount("erd1sender")
+	assert.Nil(t, err)
+	hash, err := client.SendTransaction(&api.TransactionData{Sender: "erd1sender", Receiver: "erd1receiver", Value: "1"})
+	assert.Nil(t, err)
+	server.Close()
+
+	dir, err := ioutil.TempDir("", "recorder")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "send.json")
+	assert.Nil(t, rec.Save(path))
+
+	replayer, err := recorder.LoadReplayer(path)
+	assert.Nil(t, err)
+	assert.Equal(t, 3, replayer.Remaining())
+
+	client = api.Client{Host: "http://replay.invalid", Client: &http.Client{Transport: replayer}}
+
+	account, err := client.GetAccount("erd1sender")
+	assert.Nil(t, err)
+	assert.Equal(t, first.Nonce, account.Nonce)
+
+	account, err = client.GetAccount("erd1sender")
+	assert.Nil(t, err)
+	assert.Equal(t, second.Nonce, account.Nonce)
+
+	replayedHash, err := client.SendTransaction(&api.TransactionData{Sender: "erd1sender", Receiver: "erd1receiver", Value: "1"})
+	assert.Nil(t, err)
+	assert.Equal(t, hash, replayedHash)
+	assert.Equal(t, 0, replayer.Remaining())
+
+	// Every interaction is served once unless repeats are allowed
+	_, err = client.GetAccount("erd1sender")
+	assert.True(t, errors.Is(err, recorder.ErrNoMatchingInteraction))
+
+	replayer.AllowRepeats = true
+	account, err = client.GetAccount("erd1sender")
+	assert.Nil(t, err)
+	assert.Equal(t, second.Nonce, account.Nonce)
+}
+
+func TestReplayMatchesBody(t *testing.T) {
+	t.Parallel()
+
+	body := func(tx api.TransactionData) string {
+		encoded, _ := json.Marshal(tx)
+		return string(encoded)
+	}
+
+	replayer := recorder.NewReplayer(&recorder.Fixture{
+		Interactions: []recorder.Interaction{
+			{
+				Request:  recorder.Request{Method: http.MethodPost, Path: "/transaction/send", Body: body(api.TransactionData{Nonce: 1, Value: "1"})},
+				Response: recorder.Response{StatusCode: http.StatusOK, Body: `{"txHash":"first"}`},
+			},
+			{
+				Request:  recorder.Request{Method: http.MethodPost, Path: "/transaction/send", Body: body(api.TransactionData{Nonce: 2, Value: "1"})},
+				Response: recorder.Response{StatusCode: http.StatusOK, Body: `{"txHash":"second"}`},
+			},
+		},
+	})
+	client := api.Client{Host: "http://replay.invalid", Client: &http.Client{Transport: replayer}}
+
+	hash, err := client.SendTransaction(&api.TransactionData{Nonce: 2, Value: "1"})
+	assert.Nil(t, err)
+	assert.Equal(t, "second", hash)
+
+	_, err = client.SendTransaction(&api.TransactionData{Nonce: 3, Value: "1"})
+	assert.True(t, errors.Is(err, recorder.ErrNoMatchingInteraction))
+}
+
+func TestRecordGzipResponses(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Encoding", "gzip")
+		writer := gzip.NewWriter(w)
+		writer.Write([]byte(`{"account":{"nonce":7}}`))
+		writer.Close()
+	}))
+	defer server.Close()
+
+	rec := recorder.NewRecorder(nil)
+	client := api.Client{Host: server.URL, Client: &http.Client{Transport: rec}}
+
+	account, err := client.GetAccount("erd1sender")
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(7), account.Nonce)
+
+	interactions := rec.Fixture().Interactions
+	assert.Len(t, interactions, 1)
+	assert.Equal(t, `{"account":{"nonce":7}}`, interactions[0].Response.Body)
+	assert.Empty(t, interactions[0].Response.Header.Get("Content-Encoding"))
+}
+
+func TestRecordRedactsSecrets(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "secret-key", r.URL.Query().Get("apikey"))
+		http.SetCookie(w, &http.Cookie{Name: "session", Value: "secret-session"})
+		w.Header().Set("X-Node-Version", "v1.0.133")
+		w.Write([]byte(`{"identifier":"TKN-123456","owner":"erd1owner"}`))
+	}))
+	defer server.Close()
+
+	rec := recorder.NewRecorder(nil)
+	httpClient := &http.Client{Transport: rec}
+
+	response, err := httpClient.Get(server.URL + "/tokens/TKN-123456?apikey=secret-key&size=1")
+	assert.Nil(t, err)
+	response.Body.Close()
+
+	dir, err := ioutil.TempDir("", "recorder")
+	assert.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "fixture.json")
+	assert.Nil(t, rec.Save(path))
+
+	contents, err := ioutil.ReadFile(path)
+	assert.Nil(t, err)
+	assert.NotContains(t, string(contents), "secret")
+	assert.Contains(t, string(contents), "v1.0.133")
+
+	// Requests still match the redacted recording
+	replayer, err := recorder.LoadReplayer(path)
+	assert.Nil(t, err)
+	response, err = (&http.Client{Transport: replayer}).Get("http://replayed.example/tokens/TKN-123456?apikey=secret-key&size=1")
+	assert.Nil(t, err)
+	body, _ := ioutil.ReadAll(response.Body)
+	response.Body.Close()
+	assert.Equal(t, `{"identifier":"TKN-123456","owner":"erd1owner"}`, string(body))
+
+	// Custom redaction replaces the default one
+	rec = recorder.NewRecorder(nil)
+	rec.Redact = func(interaction *recorder.Interaction) {
+		recorder.DefaultRedact(interaction)
+		interaction.Response.Body = strings.Replace(interaction.Response.Body, "erd1owner", "erd1redacted", 1)
+	}
+	response, err = (&http.Client{Transport: rec}).Get(server.URL + "/tokens/TKN-123456?apikey=secret-key")
+	assert.Nil(t, err)
+	body, _ = ioutil.ReadAll(response.Body)
+	response.Body.Close()
+
+	// Only the recording is redacted, the caller gets the real response
+	assert.Contains(t, string(body), "erd1owner")
+	assert.Contains(t, rec.Fixture().Interactions[0].Response.Body, "erd1redacted")
+}
@@ -0,0 +1,192 @@
+package api
+
+import (
+	"bufio"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	// DefaultMaxResponseSize - the maximum size of a (decompressed) response body unless configured otherwise
+	DefaultMaxResponseSize int64 = 64 << 20
+
+	// ErrResponseTooLarge - the response body exceeded the client's maximum response size
+	ErrResponseTooLarge = errors.New("response body too large")
+
+	// maxErrorBodySize - error responses are only read up to this size
+	maxErrorBodySize int64 = 64 << 10
+
+	// maxDrainSize - unread response bodies up to this size are drained so the connection can be reused
+	maxDrainSize int64 = 256 << 10
+)
+
+// ResponseHandler - consumes the body of a successful response
+type ResponseHandler func(body io.Reader) error
+
+// maxResponseSize - the client's MaxResponseSize, 0 uses DefaultMaxResponseSize and negative values disable the cap
+func (client *Client) maxResponseSize() int64 {
+	if client.MaxResponseSize == 0 {
+		return DefaultMaxResponseSize
+	}
+
+	return client.MaxResponseSize
+}
+
+// responseReader - the decompressed and size capped response body
+func (client *Client) responseReader(resp *http.Response) (io.Reader, error) {
+	var reader io.Reader = resp.Body
+
+	if strings.EqualFold(resp.Header.Get("Content-Encoding"), "gzip") {
+		gzipReader, err := gzip.NewReader(resp.Body)
+		if err != nil {
+			return nil, errors.Wrapf(err, "gzip response")
+		}
+		reader = gzipReader
+	}
+
+	if limit := client.maxResponseSize(); limit > 0 {
+		reader = &boundedReader{reader: reader, remaining: limit}
+	}
+
+	return reader, nil
+}
+
+// boundedReader - fails with ErrResponseTooLarge instead of silently truncating oversized bodies
+type boundedReader struct {
+	reader    io.Reader
+	remaining int64
+}
+
+func (bounded *boundedReader) Read(buffer []byte) (int, error) {
+	if bounded.remaining <= 0 {
+		var probe [1]byte
+		if read, err := bounded.reader.Read(probe[:]); read > 0 {
+			return 0, ErrResponseTooLarge
+		} else if err != nil {
+			return 0, err
+		}
+		return 0, nil
+	}
+
+	if int64(len(buffer)) > bounded.remaining {
+		buffer = buffer[:bounded.remaining]
+	}
+
+	read, err := bounded.reader.Read(buffer)
+	bounded.remaining -= int64(read)
+
+	return read, err
+}
+
+// drainAndClose - discards the unread part of a body so keep-alive connections can be reused
+func drainAndClose(body io.ReadCloser) {
+	io.CopyN(ioutil.Discard, body, maxDrainSize)
+	body.Close()
+}
+
+// decodeStream - decodes a field of a node response while reading it, without buffering the whole body
+// The field may be wrapped in a data envelope, list responses may also be returned as a bare array
+func decodeStream(body io.Reader, key string, target interface{}) error {
+	reader := bufio.NewReader(body)
+	decoder := json.NewDecoder(reader)
+
+	first, err := peekNonSpace(reader)
+	if err != nil {
+		return errors.Wrapf(err, "JSON Unmarshal")
+	}
+
+	if first == '[' {
+		return errors.Wrapf(decoder.Decode(target), "JSON Unmarshal")
+	}
+
+	found, err := seekField(decoder, key, true)
+	if err != nil {
+		return errors.Wrapf(err, "JSON Unmarshal")
+	}
+	if !found {
+		return fmt.Errorf("response is missing the %s field", key)
+	}
+
+	return errors.Wrapf(decoder.Decode(target), "JSON Unmarshal")
+}
+
+// seekField - advances the decoder to the value of the key, descending into the data envelope if allowed
+func seekField(decoder *json.Decoder, key string, descend bool) (bool, error) {
+	token, err := decoder.Token()
+	if err != nil {
+		return false, err
+	}
+
+	if delim, ok := token.(json.Delim); !ok || delim != '{' {
+		if ok && delim == '[' {
+			return false, skipRemaining(decoder)
+		}
+		return false, nil
+	}
+
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return false, err
+		}
+
+		name, _ := token.(string)
+		if name == key {
+			return true, nil
+		}
+
+		if descend && name == "data" {
+			found, err := seekField(decoder, key, false)
+			if found || err != nil {
+				return found, err
+			}
+			continue
+		}
+
+		var skipped json.RawMessage
+		if err := decoder.Decode(&skipped); err != nil {
+			return false, err
+		}
+	}
+
+	_, err = decoder.Token()
+
+	return false, err
+}
+
+// skipRemaining - consumes the rest of the array or object the decoder is in
+func skipRemaining(decoder *json.Decoder) error {
+	for decoder.More() {
+		var skipped json.RawMessage
+		if err := decoder.Decode(&skipped); err != nil {
+			return err
+		}
+	}
+
+	_, err := decoder.Token()
+
+	return err
+}
+
+func peekNonSpace(reader *bufio.Reader) (byte, error) {
+	for {
+		peeked, err := reader.Peek(1)
+		if err != nil {
+			return 0, err
+		}
+
+		switch peeked[0] {
+		case ' ', '\t', '\r', '\n':
+			reader.ReadByte()
+		default:
+			return peeked[0], nil
+		}
+	}
+}
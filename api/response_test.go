@@ -0,0 +1,105 @@
+package api_test
+
+import (
+	"compress/gzip"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/SebastianJ/elrond-sdk/api"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGzipResponses(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "gzip", r.Header.Get("Accept-Encoding"))
+
+		w.Header().Set("Content-Encoding", "gzip")
+		writer := gzip.NewWriter(w)
+		writer.Write([]byte(`{"data":{"hyperblock":{"nonce":42,"hash":"abcd"}},"code":"successful"}`))
+		writer.Close()
+	}))
+	defer server.Close()
+
+	client := api.Client{Host: server.URL}
+	hyperBlock, err := client.GetHyperBlockByNonce(42)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(42), hyperBlock.Nonce)
+	assert.Equal(t, "abcd", hyperBlock.Hash)
+}
+
+func TestMaxResponseSize(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"account":{"nonce":1,"balance":"` + strings.Repeat("1", 1024) + `"}}`))
+	}))
+	defer server.Close()
+
+	client := api.Client{Host: server.URL, MaxResponseSize: 512}
+	_, err := client.GetAccount("erd1test")
+	assert.True(t, errors.Is(err, api.ErrResponseTooLarge))
+
+	client = api.Client{Host: server.URL, MaxResponseSize: -1}
+	_, err = client.GetAccount("erd1test")
+	assert.Nil(t, err)
+}
+
+func TestStreamingHistoryDecoding(t *testing.T) {
+	t.Parallel()
+
+	responses := []string{
+		`[{"txHash":"a"},{"txHash":"b"}]`,
+		`{"data":{"metadata":{"ignored":[1,2,{"x":null}]},"transactions":[{"txHash":"c"}]},"code":"successful"}`,
+		`{"data":null,"transactions":[{"txHash":"d"}]}`,
+		`{"code":"successful"}`,
+	}
+
+	var index int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(responses[atomic.AddInt32(&index, 1)-1]))
+	}))
+	defer server.Close()
+
+	client := api.Client{Host: server.URL}
+	for _, expected := range []int{2, 1, 1} {
+		page, err := client.GetTransactionHistory(api.HistoryQuery{Address: "erd1test"})
+		assert.Nil(t, err)
+		assert.Len(t, page.Transactions, expected)
+	}
+
+	_, err := client.GetTransactionHistory(api.HistoryQuery{Address: "erd1test"})
+	assert.NotNil(t, err)
+}
+
+func TestResponseBodiesAreDrained(t *testing.T) {
+	t.Parallel()
+
+	var connections int32
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Trailing data after the decoded field has to be drained to reuse the connection
+		w.Write([]byte(`{"data":{"block":{"nonce":1}},"padding":"` + strings.Repeat("x", 8192) + `"}`))
+	}))
+	server.Config.ConnState = func(conn net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			atomic.AddInt32(&connections, 1)
+		}
+	}
+	server.Start()
+	defer server.Close()
+
+	client := api.Client{Host: server.URL}
+	for i := 0; i < 5; i++ {
+		block, err := client.GetBlockByNonce(0, 1, false)
+		assert.Nil(t, err)
+		assert.Equal(t, uint64(1), block.Nonce)
+	}
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&connections))
+}
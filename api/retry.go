@@ -0,0 +1,189 @@
+package api
+
+import (
+	"context"
+	"math"
+	"math/rand"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	// DefaultRetryPolicy - sensible retry defaults for reads against nodes and the central API
+	DefaultRetryPolicy = RetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: 500 * time.Millisecond,
+		MaxBackoff:     10 * time.Second,
+		Multiplier:     2,
+		Jitter:         0.2,
+	}
+
+	// queryPostPaths - POST routes that evaluate a request without changing any state
+	queryPostPaths = []string{
+		"/vm-values/query",
+		"/transaction/cost",
+		"/transaction/simulate",
+	}
+
+	// DefaultRetryPredicates - the predicates used when a policy doesn't define its own
+	DefaultRetryPredicates = []RetryPredicate{
+		RetryOnNetworkError,
+		RetryOnServerError,
+		RetryOnTooManyRequests,
+	}
+)
+
+// RetryPredicate - decides whether a failed request should be retried based on its error
+type RetryPredicate func(err error) bool
+
+// RetryPolicy - configures how failed requests are retried by the client
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first one
+	MaxAttempts    int
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	Multiplier     float64
+	// Jitter is the fraction (0-1) of each backoff that gets randomized
+	Jitter float64
+	// RetryOn defaults to DefaultRetryPredicates when empty
+	RetryOn []RetryPredicate
+	// RetryTransactions enables retries for transaction submissions,
+	// limited to failures where the node can't have accepted the transaction
+	RetryTransactions bool
+}
+
+// Backoff - calculates the wait time after a given failed attempt (starting at 1)
+func (policy *RetryPolicy) Backoff(attempt int) time.Duration {
+	if attempt < 1 {
+		attempt = 1
+	}
+
+	multiplier := policy.Multiplier
+	if multiplier < 1 {
+		multiplier = 1
+	}
+
+	backoff := float64(policy.InitialBackoff) * math.Pow(multiplier, float64(attempt-1))
+	if policy.MaxBackoff > 0 && backoff > float64(policy.MaxBackoff) {
+		backoff = float64(policy.MaxBackoff)
+	}
+
+	if policy.Jitter > 0 {
+		jitter := math.Min(policy.Jitter, 1)
+		backoff = backoff * (1 - jitter + (2 * jitter * rand.Float64()))
+	}
+
+	return time.Duration(backoff)
+}
+
+// ShouldRetry - checks if a request that failed with the given error should be retried
+func (policy *RetryPolicy) ShouldRetry(request *http.Request, err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	if !isIdempotentRequest(request) {
+		return policy.RetryTransactions && isUnprocessedError(err)
+	}
+
+	predicates := policy.RetryOn
+	if len(predicates) == 0 {
+		predicates = DefaultRetryPredicates
+	}
+
+	for _, predicate := range predicates {
+		if predicate(err) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// RetryOnNetworkError - retries requests failing due to connection issues or timeouts
+// Other errors returned by the http client, e.g. from middleware or TLS configuration, aren't retried
+func RetryOnNetworkError(err error) bool {
+	var opErr *net.OpError
+	if errors.As(err, &opErr) {
+		return true
+	}
+
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
+
+// RetryOnServerError - retries requests failing with a 5xx status code
+func RetryOnServerError(err error) bool {
+	var apiError *Error
+	return errors.As(err, &apiError) && apiError.StatusCode >= http.StatusInternalServerError
+}
+
+// RetryOnTooManyRequests - retries requests that got throttled with a 429 status code
+func RetryOnTooManyRequests(err error) bool {
+	var apiError *Error
+	return errors.As(err, &apiError) && apiError.StatusCode == http.StatusTooManyRequests
+}
+
+// isIdempotentRequest - reads can safely be sent again, including the POST routes that only query the node's state
+func isIdempotentRequest(request *http.Request) bool {
+	switch request.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	case http.MethodPost:
+		for _, path := range queryPostPaths {
+			if strings.HasSuffix(request.URL.Path, path) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// isUnprocessedError - checks if an error guarantees that the node never processed the request
+// A 503 doesn't qualify since proxies return it after forwarding the request, only throttling with a Retry-After does
+func isUnprocessedError(err error) bool {
+	var apiError *Error
+	if errors.As(err, &apiError) {
+		return apiError.StatusCode == http.StatusTooManyRequests && apiError.Header.Get("Retry-After") != ""
+	}
+
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
+func rewindRequest(request *http.Request) (*http.Request, error) {
+	if request.Body == nil || request.Body == http.NoBody {
+		return request, nil
+	}
+
+	if request.GetBody == nil {
+		return nil, errors.New("request body can't be rewound")
+	}
+
+	body, err := request.GetBody()
+	if err != nil {
+		return nil, err
+	}
+
+	rewound := request.Clone(request.Context())
+	rewound.Body = body
+
+	return rewound, nil
+}
+
+func sleepContext(ctx context.Context, duration time.Duration) error {
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,182 @@
+package api_test
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/SebastianJ/elrond-sdk/api"
+	"github.com/stretchr/testify/assert"
+)
+
+func testRetryPolicy() *api.RetryPolicy {
+	return &api.RetryPolicy{
+		MaxAttempts:    3,
+		InitialBackoff: time.Millisecond,
+		MaxBackoff:     5 * time.Millisecond,
+		Multiplier:     2,
+	}
+}
+
+func TestRetryPolicyRetriesServerErrors(t *testing.T) {
+	t.Parallel()
+
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&requests, 1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"account":{"nonce":5,"balance":"1000000000000000000"}}`))
+	}))
+	defer server.Close()
+
+	client := api.Client{Host: server.URL, RetryPolicy: testRetryPolicy()}
+	account, err := client.GetAccount("erd1test")
+
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(5), account.Nonce)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&requests))
+}
+
+func TestRetryPolicyStopsAfterMaxAttempts(t *testing.T) {
+	t.Parallel()
+
+	var requests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	client := api.Client{Host: server.URL, RetryPolicy: testRetryPolicy()}
+	_, err := client.GetAccount("erd1test")
+
+	assert.NotNil(t, err)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&requests))
+}
+
+func TestRetryPolicySkipsTransactionSubmissions(t *testing.T) {
+	t.Parallel()
+
+	var requests int32
+	var throttled int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		if atomic.LoadInt32(&throttled) == 1 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := api.Client{Host: server.URL, RetryPolicy: testRetryPolicy()}
+	_, err := client.SendTransaction(&api.TransactionData{})
+	assert.NotNil(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&requests))
+
+	// A 503 doesn't guarantee that the transaction wasn't forwarded, so it's never retried
+	client.RetryPolicy.RetryTransactions = true
+	_, err = client.SendTransaction(&api.TransactionData{})
+	assert.NotNil(t, err)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&requests))
+
+	atomic.StoreInt32(&throttled, 1)
+	_, err = client.SendTransaction(&api.TransactionData{})
+	assert.NotNil(t, err)
+	assert.Equal(t, int32(5), atomic.LoadInt32(&requests))
+}
+
+func TestRetryPolicyRetriesQueries(t *testing.T) {
+	t.Parallel()
+
+	var queries, submissions int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/vm-values/query":
+			if atomic.AddInt32(&queries, 1) < 3 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			w.Write([]byte(`{"data":{"data":{"returnData":["AQ=="],"returnCode":"ok"}}}`))
+		default:
+			atomic.AddInt32(&submissions, 1)
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+
+	client := api.Client{Host: server.URL, RetryPolicy: testRetryPolicy()}
+
+	// Queries are POST requests but don't change any state, so they're retried like reads
+	_, err := client.QueryVM(api.VMQuery{ScAddress: "erd1contract", FuncName: "getSum"})
+	assert.Nil(t, err)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&queries))
+
+	_, err = client.SendTransaction(&api.TransactionData{})
+	assert.NotNil(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&submissions))
+}
+
+func TestRetryOnNetworkError(t *testing.T) {
+	t.Parallel()
+
+	dialErr := &url.Error{Op: "Get", URL: "http://127.0.0.1:1", Err: &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}}
+	assert.True(t, api.RetryOnNetworkError(dialErr))
+	assert.True(t, api.RetryOnNetworkError(&url.Error{Op: "Get", URL: "http://127.0.0.1:1", Err: timeoutError{}}))
+
+	// The http client wraps every error in a *url.Error, which on its own doesn't make it a network error
+	assert.False(t, api.RetryOnNetworkError(&url.Error{Op: "Get", URL: "http://127.0.0.1:1", Err: errors.New("middleware failure")}))
+	assert.False(t, api.RetryOnNetworkError(errors.New("middleware failure")))
+}
+
+func TestRetryPolicySkipsMiddlewareErrors(t *testing.T) {
+	t.Parallel()
+
+	first := newStatusServer(http.StatusOK)
+	defer first.Close()
+	second := newStatusServer(http.StatusOK)
+	defer second.Close()
+
+	client, err := api.NewPooledClient([]string{first.URL, second.URL}, api.PoolOptions{FailureThreshold: 1})
+	assert.Nil(t, err)
+
+	var requests int32
+	client.Middleware = []api.Middleware{func(next api.RequestHandler) api.RequestHandler {
+		return func(request *http.Request) (*http.Response, error) {
+			atomic.AddInt32(&requests, 1)
+			return nil, errors.New("middleware failure")
+		}
+	}}
+
+	_, err = client.Status()
+	assert.NotNil(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&requests))
+	for _, endpoint := range client.Pool.Endpoints() {
+		assert.True(t, endpoint.Healthy)
+	}
+}
+
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "i/o timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	t.Parallel()
+
+	policy := api.RetryPolicy{InitialBackoff: time.Second, MaxBackoff: 5 * time.Second, Multiplier: 2}
+
+	assert.Equal(t, time.Second, policy.Backoff(1))
+	assert.Equal(t, 2*time.Second, policy.Backoff(2))
+	assert.Equal(t, 4*time.Second, policy.Backoff(3))
+	assert.Equal(t, 5*time.Second, policy.Backoff(4))
+}
@@ -0,0 +1,133 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// SimulationResult - the outcome of simulating a transaction against the current state
+// Cross shard transactions report the outcome in each shard separately
+type SimulationResult struct {
+	Status               TransactionStatus              `json:"status"`
+	FailReason           string                         `json:"failReason,omitempty"`
+	Hash                 string                         `json:"hash,omitempty"`
+	GasUsed              uint64                         `json:"gasUsed,omitempty"`
+	SmartContractResults map[string]SmartContractResult `json:"scResults,omitempty"`
+	Receipts             map[string]Receipt             `json:"receipts,omitempty"`
+	SenderShard          *SimulationResult              `json:"senderShard,omitempty"`
+	ReceiverShard        *SimulationResult              `json:"receiverShard,omitempty"`
+}
+
+// SimulateTransaction executes a signed transaction against the current state without committing it
+func (client *Client) SimulateTransaction(txData *TransactionData) (SimulationResult, error) {
+	return client.SimulateTransactionContext(context.Background(), txData)
+}
+
+// SimulateTransactionContext executes a signed transaction against the current state without committing it using the supplied context
+func (client *Client) SimulateTransactionContext(ctx context.Context, txData *TransactionData) (SimulationResult, error) {
+	client.Initialize()
+
+	var result SimulationResult
+
+	url := fmt.Sprintf("%s/transaction/simulate", client.host())
+
+	jsonData, err := json.Marshal(txData)
+	if err != nil {
+		return result, errors.Wrapf(err, "JSON Marshal")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return result, errors.Wrapf(err, "HTTP NewRequest")
+	}
+
+	body, err := client.PerformRequest(url, req)
+	if err != nil {
+		return result, errors.Wrapf(err, "Client PerformRequest")
+	}
+
+	if err := decodeResponse(body, "result", &result); err != nil {
+		return result, err
+	}
+
+	return result, nil
+}
+
+// Successful - checks if the transaction would execute successfully, in every shard it touches
+// A result without a status isn't considered successful
+func (result SimulationResult) Successful() bool {
+	if result.SenderShard != nil || result.ReceiverShard != nil {
+		return (result.SenderShard == nil || result.SenderShard.Successful()) &&
+			(result.ReceiverShard == nil || result.ReceiverShard.Successful())
+	}
+
+	return result.FailReason == "" && result.Status.IsSuccessful()
+}
+
+// ReturnData - the values returned by the smart contract calls, decoded from the smart contract results
+// The results are ordered by nonce and then hash, since the node returns them keyed by hash
+func (result SimulationResult) ReturnData() ([][]byte, error) {
+	returnData := [][]byte{}
+
+	for _, shardResult := range []*SimulationResult{result.SenderShard, result.ReceiverShard} {
+		if shardResult == nil {
+			continue
+		}
+
+		shardData, err := shardResult.ReturnData()
+		if err != nil {
+			return nil, err
+		}
+		returnData = append(returnData, shardData...)
+	}
+
+	for _, scResult := range result.orderedSmartContractResults() {
+		if !strings.HasPrefix(scResult.Data, "@") {
+			continue
+		}
+
+		// Data is formatted as @<return code>@<value>@<value>...
+		parts := strings.Split(scResult.Data, "@")
+		if len(parts) < 3 {
+			continue
+		}
+
+		for _, part := range parts[2:] {
+			decoded, err := hex.DecodeString(part)
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid return data in smart contract result %s", scResult.Hash)
+			}
+			returnData = append(returnData, decoded)
+		}
+	}
+
+	return returnData, nil
+}
+
+// orderedSmartContractResults - the smart contract results sorted by nonce and then hash
+func (result SimulationResult) orderedSmartContractResults() []SmartContractResult {
+	scResults := make([]SmartContractResult, 0, len(result.SmartContractResults))
+	for hash, scResult := range result.SmartContractResults {
+		if scResult.Hash == "" {
+			scResult.Hash = hash
+		}
+		scResults = append(scResults, scResult)
+	}
+
+	sort.Slice(scResults, func(i, j int) bool {
+		if scResults[i].Nonce != scResults[j].Nonce {
+			return scResults[i].Nonce < scResults[j].Nonce
+		}
+		return scResults[i].Hash < scResults[j].Hash
+	})
+
+	return scResults
+}
@@ -0,0 +1,62 @@
+package api_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/SebastianJ/elrond-sdk/api"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSimulationReturnDataOrder(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"data":{"result":{"status":"success","scResults":{
+			"ccc":{"hash":"ccc","nonce":2,"data":"@6f6b@03"},
+			"bbb":{"hash":"bbb","nonce":1,"data":"@6f6b@02"},
+			"aaa":{"hash":"aaa","nonce":1,"data":"@6f6b@01"},
+			"ddd":{"hash":"ddd","nonce":0,"data":"transfer"}
+		}}}}`))
+	}))
+	defer server.Close()
+
+	client := api.Client{Host: server.URL}
+
+	for i := 0; i < 10; i++ {
+		result, err := client.SimulateTransaction(&api.TransactionData{})
+		assert.Nil(t, err)
+		assert.True(t, result.Successful())
+
+		returnData, err := result.ReturnData()
+		assert.Nil(t, err)
+		assert.Equal(t, [][]byte{{0x01}, {0x02}, {0x03}}, returnData)
+	}
+}
+
+func TestSimulationResultSuccessful(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		name       string
+		result     api.SimulationResult
+		successful bool
+	}{
+		{name: "success", result: api.SimulationResult{Status: api.TxStatusSuccess}, successful: true},
+		{name: "missing status", result: api.SimulationResult{}, successful: false},
+		{name: "fail reason", result: api.SimulationResult{Status: api.TxStatusSuccess, FailReason: "out of gas"}, successful: false},
+		{name: "cross shard", result: api.SimulationResult{
+			SenderShard:   &api.SimulationResult{Status: api.TxStatusSuccess},
+			ReceiverShard: &api.SimulationResult{Status: api.TxStatusSuccess},
+		}, successful: true},
+		{name: "cross shard without receiver status", result: api.SimulationResult{
+			SenderShard:   &api.SimulationResult{Status: api.TxStatusSuccess},
+			ReceiverShard: &api.SimulationResult{},
+		}, successful: false},
+	}
+
+	for _, testCase := range testCases {
+		assert.Equal(t, testCase.successful, testCase.result.Successful(), testCase.name)
+	}
+}
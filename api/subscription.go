@@ -0,0 +1,368 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// BalanceChanged - the balance of a watched address changed
+	BalanceChanged EventType = iota
+	// NonceChanged - the nonce of a watched address changed, i.e. it sent transactions
+	NonceChanged
+	// IncomingTransfer - a watched address received a transfer
+	IncomingTransfer
+)
+
+const (
+	// WatchAccounts - detects activity by polling the state of every watched account
+	WatchAccounts WatchMode = iota
+	// WatchHyperBlocks - detects activity by scanning every new hyperblock, which also reveals the sender and data of transfers
+	WatchHyperBlocks
+)
+
+var (
+	// DefaultSubscriptionOptions - default options used for address subscriptions
+	DefaultSubscriptionOptions = SubscriptionOptions{
+		Mode:                  WatchAccounts,
+		PollInterval:          6 * time.Second,
+		BufferSize:            100,
+		MaxHyperBlockAttempts: 5,
+	}
+
+	// ErrHyperBlockSkipped - a hyperblock kept failing to load and was skipped, so activity in it wasn't detected
+	ErrHyperBlockSkipped = errors.New("hyperblock skipped")
+)
+
+// EventType - the kind of activity an account event describes
+type EventType int
+
+// WatchMode - how a subscription detects account activity
+type WatchMode int
+
+// AccountEvent - activity detected for a watched address
+type AccountEvent struct {
+	Type       EventType
+	Address    string
+	OldBalance *big.Int
+	NewBalance *big.Int
+	OldNonce   uint64
+	NewNonce   uint64
+	// Transfer is only set for IncomingTransfer events
+	Transfer *TransactionInfo
+	Time     time.Time
+}
+
+// SubscriptionOptions - configures an address subscription
+type SubscriptionOptions struct {
+	Mode         WatchMode
+	PollInterval time.Duration
+	BufferSize   int
+	// StartHyperBlockNonce is the first hyperblock scanned in WatchHyperBlocks mode, defaults to the current metachain nonce
+	StartHyperBlockNonce uint64
+	// MaxHyperBlockAttempts is the number of polls a failing hyperblock is retried before it's skipped
+	MaxHyperBlockAttempts int
+}
+
+// Subscription - watches a set of addresses and delivers their activity as events
+type Subscription struct {
+	client    *Client
+	options   SubscriptionOptions
+	mutex     sync.Mutex
+	addresses map[string]*Account
+	nextNonce uint64
+	failures  int
+	events    chan AccountEvent
+	errors    chan error
+	cancel    context.CancelFunc
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+// Subscribe starts watching the given addresses until the subscription is closed
+func (client *Client) Subscribe(addresses []string, options SubscriptionOptions) *Subscription {
+	if options.PollInterval <= 0 {
+		options.PollInterval = DefaultSubscriptionOptions.PollInterval
+	}
+
+	if options.BufferSize <= 0 {
+		options.BufferSize = DefaultSubscriptionOptions.BufferSize
+	}
+
+	if options.MaxHyperBlockAttempts <= 0 {
+		options.MaxHyperBlockAttempts = DefaultSubscriptionOptions.MaxHyperBlockAttempts
+	}
+
+	client.Initialize()
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	subscription := &Subscription{
+		client:    client,
+		options:   options,
+		addresses: make(map[string]*Account),
+		nextNonce: options.StartHyperBlockNonce,
+		events:    make(chan AccountEvent, options.BufferSize),
+		errors:    make(chan error, options.BufferSize),
+		cancel:    cancel,
+		done:      make(chan struct{}),
+	}
+
+	subscription.Add(addresses...)
+
+	go subscription.run(ctx)
+
+	return subscription
+}
+
+// Events - the channel account events are delivered on, closed when the subscription is closed
+func (subscription *Subscription) Events() <-chan AccountEvent {
+	return subscription.events
+}
+
+// Errors - the channel polling errors are delivered on, errors are dropped when nobody reads them
+func (subscription *Subscription) Errors() <-chan error {
+	return subscription.errors
+}
+
+// Add - starts watching additional addresses
+func (subscription *Subscription) Add(addresses ...string) {
+	subscription.mutex.Lock()
+	defer subscription.mutex.Unlock()
+
+	for _, address := range addresses {
+		if _, ok := subscription.addresses[address]; !ok {
+			subscription.addresses[address] = nil
+		}
+	}
+}
+
+// Remove - stops watching the given addresses
+func (subscription *Subscription) Remove(addresses ...string) {
+	subscription.mutex.Lock()
+	defer subscription.mutex.Unlock()
+
+	for _, address := range addresses {
+		delete(subscription.addresses, address)
+	}
+}
+
+// Addresses - the addresses currently being watched
+func (subscription *Subscription) Addresses() []string {
+	subscription.mutex.Lock()
+	defer subscription.mutex.Unlock()
+
+	addresses := make([]string, 0, len(subscription.addresses))
+	for address := range subscription.addresses {
+		addresses = append(addresses, address)
+	}
+
+	return addresses
+}
+
+// Close - stops watching, waits for the polling to finish and closes the event and error channels
+func (subscription *Subscription) Close() {
+	subscription.closeOnce.Do(func() {
+		subscription.cancel()
+		<-subscription.done
+		close(subscription.events)
+		close(subscription.errors)
+	})
+}
+
+func (subscription *Subscription) run(ctx context.Context) {
+	defer close(subscription.done)
+
+	ticker := time.NewTicker(subscription.options.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		subscription.poll(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (subscription *Subscription) poll(ctx context.Context) {
+	if subscription.options.Mode == WatchHyperBlocks {
+		subscription.scanHyperBlocks(ctx)
+		return
+	}
+
+	for _, address := range subscription.Addresses() {
+		subscription.refreshAccount(ctx, address)
+	}
+}
+
+// scanHyperBlocks - processes every hyperblock since the last scan, refreshing the accounts involved in transactions
+func (subscription *Subscription) scanHyperBlocks(ctx context.Context) {
+	// Hyperblocks are metachain blocks, so scanning starts from the metachain nonce rather than the nonce of the node's shard
+	if subscription.nextNonce == 0 {
+		status, err := subscription.client.GetNetworkStatusContext(ctx, MetachainShardID)
+		if err != nil {
+			subscription.reportError(err)
+			return
+		}
+		subscription.nextNonce = status.Nonce
+	}
+
+	for ctx.Err() == nil {
+		hyperBlock, err := subscription.client.GetHyperBlockByNonceContext(ctx, subscription.nextNonce)
+		if err != nil {
+			var apiError *Error
+			if errors.As(err, &apiError) && apiError.StatusCode == http.StatusNotFound {
+				// The hyperblock hasn't been produced yet
+				return
+			}
+
+			// Gateways can also answer a 5xx for nonces past the chain tip, only hyperblocks that were produced count as failed
+			if ctx.Err() == nil && !subscription.nextHyperBlockProduced(ctx) {
+				return
+			}
+
+			subscription.reportError(err)
+			if subscription.failures++; subscription.failures < subscription.options.MaxHyperBlockAttempts || errors.Is(err, context.Canceled) {
+				return
+			}
+
+			subscription.reportError(fmt.Errorf("%w: nonce %d: %v", ErrHyperBlockSkipped, subscription.nextNonce, err))
+			subscription.failures = 0
+			subscription.nextNonce++
+			continue
+		}
+
+		subscription.failures = 0
+
+		touched := make(map[string]bool)
+
+		for index := range hyperBlock.Transactions {
+			transaction := hyperBlock.Transactions[index]
+
+			if subscription.isWatched(transaction.Sender) {
+				touched[transaction.Sender] = true
+			}
+
+			if subscription.isWatched(transaction.Receiver) {
+				touched[transaction.Receiver] = true
+
+				if transaction.Status.IsSuccessful() {
+					subscription.emit(ctx, AccountEvent{
+						Type:     IncomingTransfer,
+						Address:  transaction.Receiver,
+						Transfer: &transaction,
+						Time:     time.Unix(hyperBlock.Timestamp, 0),
+					})
+				}
+			}
+		}
+
+		for address := range touched {
+			subscription.refreshAccount(ctx, address)
+		}
+
+		subscription.nextNonce++
+	}
+}
+
+// nextHyperBlockProduced - checks that the next hyperblock isn't beyond the metachain nonce
+// When the metachain status can't be fetched the hyperblock is assumed not to exist yet, so it's retried on the next poll
+func (subscription *Subscription) nextHyperBlockProduced(ctx context.Context) bool {
+	status, err := subscription.client.GetNetworkStatusContext(ctx, MetachainShardID)
+	if err != nil {
+		subscription.reportError(err)
+		return false
+	}
+
+	return subscription.nextNonce <= status.Nonce
+}
+
+// refreshAccount - fetches the state of a watched account and emits events for any changes since the last poll
+func (subscription *Subscription) refreshAccount(ctx context.Context, address string) {
+	account, err := subscription.client.GetAccountContext(ctx, address)
+	if err != nil {
+		subscription.reportError(err)
+		return
+	}
+
+	subscription.mutex.Lock()
+	previous, watched := subscription.addresses[address]
+	if watched {
+		subscription.addresses[address] = &account
+	}
+	subscription.mutex.Unlock()
+
+	// The first poll of an address only records its state
+	if !watched || previous == nil {
+		return
+	}
+
+	now := time.Now()
+
+	if previous.Nonce != account.Nonce {
+		subscription.emit(ctx, AccountEvent{
+			Type:     NonceChanged,
+			Address:  address,
+			OldNonce: previous.Nonce,
+			NewNonce: account.Nonce,
+			Time:     now,
+		})
+	}
+
+	if previous.BalanceString != account.BalanceString {
+		subscription.emit(ctx, AccountEvent{
+			Type:       BalanceChanged,
+			Address:    address,
+			OldBalance: parseBigInt(previous.BalanceString),
+			NewBalance: parseBigInt(account.BalanceString),
+			OldNonce:   previous.Nonce,
+			NewNonce:   account.Nonce,
+			Time:       now,
+		})
+	}
+}
+
+func (subscription *Subscription) isWatched(address string) bool {
+	subscription.mutex.Lock()
+	defer subscription.mutex.Unlock()
+
+	_, ok := subscription.addresses[address]
+
+	return ok
+}
+
+func (subscription *Subscription) emit(ctx context.Context, event AccountEvent) {
+	select {
+	case subscription.events <- event:
+	case <-ctx.Done():
+	}
+}
+
+func (subscription *Subscription) reportError(err error) {
+	if errors.Is(err, context.Canceled) {
+		return
+	}
+
+	select {
+	case subscription.errors <- err:
+	default:
+	}
+}
+
+func parseBigInt(value string) *big.Int {
+	parsed, ok := new(big.Int).SetString(value, 10)
+	if !ok {
+		return new(big.Int)
+	}
+
+	return parsed
+}
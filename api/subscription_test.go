@@ -0,0 +1,160 @@
+package api_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/SebastianJ/elrond-sdk/api"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSubscriptionAccountEvents(t *testing.T) {
+	t.Parallel()
+
+	var polls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&polls, 1) == 1 {
+			w.Write([]byte(`{"account":{"nonce":1,"balance":"100"}}`))
+			return
+		}
+		w.Write([]byte(`{"account":{"nonce":2,"balance":"250"}}`))
+	}))
+	defer server.Close()
+
+	client := api.Client{Host: server.URL}
+	subscription := client.Subscribe([]string{"erd1watched"}, api.SubscriptionOptions{PollInterval: 10 * time.Millisecond})
+
+	nonceEvent := <-subscription.Events()
+	assert.Equal(t, api.NonceChanged, nonceEvent.Type)
+	assert.Equal(t, uint64(1), nonceEvent.OldNonce)
+	assert.Equal(t, uint64(2), nonceEvent.NewNonce)
+
+	balanceEvent := <-subscription.Events()
+	assert.Equal(t, api.BalanceChanged, balanceEvent.Type)
+	assert.Equal(t, "erd1watched", balanceEvent.Address)
+	assert.Equal(t, int64(100), balanceEvent.OldBalance.Int64())
+	assert.Equal(t, int64(250), balanceEvent.NewBalance.Int64())
+
+	subscription.Remove("erd1watched")
+	assert.Empty(t, subscription.Addresses())
+
+	subscription.Close()
+	_, open := <-subscription.Events()
+	assert.False(t, open)
+}
+
+func TestSubscriptionHyperBlockTransfers(t *testing.T) {
+	t.Parallel()
+
+	// Scanning starts at nonce 10, by the time hyperblock 11 fails the chain has moved on
+	var statusRequests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/network/status/4294967295":
+			if atomic.AddInt32(&statusRequests, 1) == 1 {
+				w.Write([]byte(`{"data":{"status":{"erd_nonce":10}}}`))
+				return
+			}
+			w.Write([]byte(`{"data":{"status":{"erd_nonce":12}}}`))
+		case r.URL.Path == "/hyperblock/by-nonce/10":
+			w.Write([]byte(`{"data":{"hyperblock":{"nonce":10,"transactions":[
+				{"hash":"tx0","sender":"erd1sender","receiver":"erd1watched","value":"100","status":"fail"},
+				{"hash":"tx1","sender":"erd1sender","receiver":"erd1watched","value":"500","data":"aGk=","status":"success"}
+			]}}}`))
+		case r.URL.Path == "/hyperblock/by-nonce/11":
+			w.WriteHeader(http.StatusInternalServerError)
+		case r.URL.Path == "/hyperblock/by-nonce/12":
+			w.Write([]byte(`{"data":{"hyperblock":{"nonce":12,"transactions":[{"hash":"tx2","sender":"erd1sender","receiver":"erd1watched","value":"700","status":"success"}]}}}`))
+		case strings.HasPrefix(r.URL.Path, "/hyperblock/"):
+			w.WriteHeader(http.StatusNotFound)
+		default:
+			w.Write([]byte(fmt.Sprintf(`{"account":{"nonce":0,"balance":"%d"}}`, 500)))
+		}
+	}))
+	defer server.Close()
+
+	client := api.Client{Host: server.URL}
+	subscription := client.Subscribe([]string{"erd1watched"}, api.SubscriptionOptions{
+		Mode:                  api.WatchHyperBlocks,
+		PollInterval:          10 * time.Millisecond,
+		MaxHyperBlockAttempts: 2,
+	})
+	defer subscription.Close()
+
+	// The failed transfer isn't reported
+	event := <-subscription.Events()
+	assert.Equal(t, api.IncomingTransfer, event.Type)
+	assert.Equal(t, "tx1", event.Transfer.Hash)
+	assert.Equal(t, "erd1sender", event.Transfer.Sender)
+	assert.Equal(t, "500", event.Transfer.Value)
+	assert.Equal(t, "aGk=", event.Transfer.Data)
+
+	// The failing hyperblock gets skipped after the configured number of attempts
+	event = <-subscription.Events()
+	assert.Equal(t, api.IncomingTransfer, event.Type)
+	assert.Equal(t, "tx2", event.Transfer.Hash)
+
+	var skipped bool
+	for len(subscription.Errors()) > 0 {
+		if err := <-subscription.Errors(); errors.Is(err, api.ErrHyperBlockSkipped) {
+			skipped = true
+		}
+	}
+	assert.True(t, skipped)
+}
+
+func TestSubscriptionWaitsAtChainTip(t *testing.T) {
+	t.Parallel()
+
+	// Hyperblocks past the chain tip fail with a 5xx instead of a 404
+	var tip, pastTipRequests int32 = 10, 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/network/status/4294967295":
+			w.Write([]byte(fmt.Sprintf(`{"data":{"status":{"erd_nonce":%d}}}`, atomic.LoadInt32(&tip))))
+		case r.URL.Path == "/hyperblock/by-nonce/10":
+			w.Write([]byte(`{"data":{"hyperblock":{"nonce":10,"transactions":[]}}}`))
+		case r.URL.Path == "/hyperblock/by-nonce/11" && atomic.LoadInt32(&tip) >= 11:
+			w.Write([]byte(`{"data":{"hyperblock":{"nonce":11,"transactions":[{"hash":"tx1","sender":"erd1sender","receiver":"erd1watched","value":"500","status":"success"}]}}}`))
+		case strings.HasPrefix(r.URL.Path, "/hyperblock/"):
+			atomic.AddInt32(&pastTipRequests, 1)
+			w.WriteHeader(http.StatusInternalServerError)
+		default:
+			w.Write([]byte(`{"account":{"nonce":0,"balance":"500"}}`))
+		}
+	}))
+	defer server.Close()
+
+	client := api.Client{Host: server.URL}
+	subscription := client.Subscribe([]string{"erd1watched"}, api.SubscriptionOptions{
+		Mode:                  api.WatchHyperBlocks,
+		PollInterval:          5 * time.Millisecond,
+		MaxHyperBlockAttempts: 2,
+	})
+	defer subscription.Close()
+
+	for atomic.LoadInt32(&pastTipRequests) < 5 {
+		time.Sleep(5 * time.Millisecond)
+	}
+	atomic.StoreInt32(&tip, 11)
+
+	// Hyperblock 11 wasn't skipped while it didn't exist yet
+	select {
+	case event := <-subscription.Events():
+		assert.Equal(t, api.IncomingTransfer, event.Type)
+		assert.Equal(t, "tx1", event.Transfer.Hash)
+	case <-time.After(5 * time.Second):
+		t.Fatal("hyperblock 11 was skipped")
+	}
+
+	for len(subscription.Errors()) > 0 {
+		err := <-subscription.Errors()
+		assert.False(t, errors.Is(err, api.ErrHyperBlockSkipped), "%v", err)
+	}
+}
@@ -0,0 +1,128 @@
+package api
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	// ErrUnsupportedProxyScheme - the proxy url uses a scheme other than http, https, socks5 or socks5h
+	ErrUnsupportedProxyScheme = errors.New("unsupported proxy scheme")
+
+	// ErrTransportConflict - proxy or TLS settings were supplied together with a transport they can't be applied to
+	ErrTransportConflict = errors.New("proxy and TLS settings require an *http.Transport")
+
+	// ErrInvalidCACertificates - the supplied CA bundle didn't contain any PEM encoded certificates
+	ErrInvalidCACertificates = errors.New("no valid CA certificates found")
+)
+
+// DefaultMinTLSVersion - the minimum TLS version used unless configured otherwise
+var DefaultMinTLSVersion uint16 = tls.VersionTLS12
+
+// TLSConfig - TLS settings for connections to nodes
+// Certificate verification is only disabled when InsecureSkipVerify is explicitly set
+type TLSConfig struct {
+	// CAFile and CAPEM hold PEM encoded CA certificates trusted in addition to the system roots
+	CAFile string
+	CAPEM  []byte
+
+	// CertFile and KeyFile hold the client certificate and key used for mutual TLS
+	CertFile string
+	KeyFile  string
+
+	MinVersion         uint16
+	ServerName         string
+	InsecureSkipVerify bool
+}
+
+// Build - converts the settings to a tls.Config
+func (config *TLSConfig) Build() (*tls.Config, error) {
+	tlsConfig := &tls.Config{
+		MinVersion:         DefaultMinTLSVersion,
+		ServerName:         config.ServerName,
+		InsecureSkipVerify: config.InsecureSkipVerify,
+	}
+
+	if config.MinVersion != 0 {
+		tlsConfig.MinVersion = config.MinVersion
+	}
+
+	if config.CAFile != "" || len(config.CAPEM) > 0 {
+		pool, err := x509.SystemCertPool()
+		if err != nil || pool == nil {
+			pool = x509.NewCertPool()
+		}
+
+		bundle := config.CAPEM
+		if config.CAFile != "" {
+			contents, err := ioutil.ReadFile(config.CAFile)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to read CA file %s", config.CAFile)
+			}
+			bundle = append(append([]byte{}, bundle...), contents...)
+		}
+
+		if !pool.AppendCertsFromPEM(bundle) {
+			return nil, ErrInvalidCACertificates
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if config.CertFile != "" || config.KeyFile != "" {
+		certificate, err := tls.LoadX509KeyPair(config.CertFile, config.KeyFile)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to load client certificate")
+		}
+		tlsConfig.Certificates = []tls.Certificate{certificate}
+	}
+
+	return tlsConfig, nil
+}
+
+// NewTransport - creates a transport using the supplied proxy url and TLS settings
+// Proxies can use the http, https, socks5 and socks5h schemes
+func NewTransport(proxy string, config *TLSConfig) (*http.Transport, error) {
+	if config == nil {
+		config = &TLSConfig{}
+	}
+
+	return configureTransport(http.DefaultTransport.(*http.Transport), proxy, config)
+}
+
+// configureTransport - applies the proxy and TLS settings to a clone of the transport, settings left empty keep the transport's own
+func configureTransport(base *http.Transport, proxy string, config *TLSConfig) (*http.Transport, error) {
+	transport := base.Clone()
+
+	if proxy != "" {
+		proxyURL, err := url.Parse(proxy)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid proxy url")
+		}
+
+		switch proxyURL.Scheme {
+		case "http", "https", "socks5":
+		case "socks5h":
+			// Older Go versions only know socks5, which already resolves host names through the proxy
+			proxyURL.Scheme = "socks5"
+		default:
+			return nil, errors.Wrapf(ErrUnsupportedProxyScheme, "%q", proxyURL.Scheme)
+		}
+
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	if config != nil {
+		tlsConfig, err := config.Build()
+		if err != nil {
+			return nil, err
+		}
+		transport.TLSClientConfig = tlsConfig
+	}
+
+	return transport, nil
+}
@@ -0,0 +1,157 @@
+package api_test
+
+import (
+	"crypto/tls"
+	"encoding/pem"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/SebastianJ/elrond-sdk/api"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTLSServer() *httptest.Server {
+	return httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"account":{"nonce":1}}`))
+	}))
+}
+
+func TestTLSVerificationEnabledByDefault(t *testing.T) {
+	t.Parallel()
+
+	server := newTLSServer()
+	defer server.Close()
+
+	// Configuring a proxy must not disable certificate verification
+	client := api.Client{Host: server.URL, Proxy: "http://127.0.0.1:1"}
+	assert.Nil(t, client.Configure())
+
+	transport := client.Client.Transport.(*http.Transport)
+	assert.False(t, transport.TLSClientConfig.InsecureSkipVerify)
+	assert.Equal(t, uint16(tls.VersionTLS12), transport.TLSClientConfig.MinVersion)
+
+	client = api.Client{Host: server.URL, TLS: &api.TLSConfig{}}
+	_, err := client.GetAccount("erd1test")
+	assert.NotNil(t, err)
+}
+
+func TestTLSCustomCA(t *testing.T) {
+	t.Parallel()
+
+	server := newTLSServer()
+	defer server.Close()
+
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	client := api.Client{Host: server.URL, TLS: &api.TLSConfig{CAPEM: caPEM}}
+
+	account, err := client.GetAccount("erd1test")
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(1), account.Nonce)
+
+	client = api.Client{Host: server.URL, TLS: &api.TLSConfig{CAPEM: []byte("not a certificate")}}
+	_, err = client.GetAccount("erd1test")
+	assert.True(t, errors.Is(err, api.ErrInvalidCACertificates))
+}
+
+func TestTLSInsecureSkipVerifyOptIn(t *testing.T) {
+	t.Parallel()
+
+	server := newTLSServer()
+	defer server.Close()
+
+	client := api.Client{Host: server.URL, TLS: &api.TLSConfig{InsecureSkipVerify: true}}
+	_, err := client.GetAccount("erd1test")
+	assert.Nil(t, err)
+}
+
+func TestTLSAppliesSettingsToCallerTransport(t *testing.T) {
+	t.Parallel()
+
+	server := newTLSServer()
+	defer server.Close()
+
+	callerClient := server.Client()
+	callerTransport := callerClient.Transport.(*http.Transport)
+
+	// The proxy is applied to a copy of the caller's transport instead of being ignored
+	client := api.Client{Host: server.URL, Client: callerClient, Proxy: "socks5://127.0.0.1:1"}
+	assert.Nil(t, client.Configure())
+	assert.NotEqual(t, callerClient, client.Client)
+	assert.Equal(t, callerTransport, callerClient.Transport)
+	assert.Nil(t, callerTransport.Proxy)
+
+	_, err := client.GetAccount("erd1test")
+	assert.NotNil(t, err)
+
+	// TLS settings replace the caller's TLS config on the copy, leaving the caller's transport untouched
+	caPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	client = api.Client{Host: server.URL, Client: callerClient, TLS: &api.TLSConfig{CAPEM: caPEM, MinVersion: tls.VersionTLS13}}
+	_, err = client.GetAccount("erd1test")
+	assert.Nil(t, err)
+	assert.Equal(t, uint16(tls.VersionTLS13), client.Client.Transport.(*http.Transport).TLSClientConfig.MinVersion)
+	assert.NotEqual(t, uint16(tls.VersionTLS13), callerTransport.TLSClientConfig.MinVersion)
+}
+
+type roundTripperFunc func(request *http.Request) (*http.Response, error)
+
+func (fn roundTripperFunc) RoundTrip(request *http.Request) (*http.Response, error) {
+	return fn(request)
+}
+
+func TestTLSConflictingTransport(t *testing.T) {
+	t.Parallel()
+
+	transport := roundTripperFunc(func(request *http.Request) (*http.Response, error) {
+		return nil, errors.New("unexpected request")
+	})
+
+	client := api.Client{Host: "https://node.example", Client: &http.Client{Transport: transport}, TLS: &api.TLSConfig{MinVersion: tls.VersionTLS13}}
+	assert.True(t, errors.Is(client.Configure(), api.ErrTransportConflict))
+
+	_, err := client.GetAccount("erd1test")
+	assert.True(t, errors.Is(err, api.ErrTransportConflict))
+
+	// Without proxy or TLS settings any transport can be used
+	client.TLS = nil
+	assert.Nil(t, client.Configure())
+}
+
+func TestNewTransportProxySchemes(t *testing.T) {
+	t.Parallel()
+
+	for _, proxy := range []string{"http://127.0.0.1:8080", "https://127.0.0.1:8443", "socks5://127.0.0.1:1080", "socks5h://127.0.0.1:1080"} {
+		transport, err := api.NewTransport(proxy, nil)
+		assert.Nil(t, err)
+		assert.NotNil(t, transport.Proxy)
+	}
+
+	_, err := api.NewTransport("ftp://127.0.0.1:21", nil)
+	assert.True(t, errors.Is(err, api.ErrUnsupportedProxyScheme))
+}
+
+func TestConfigureSharedClient(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"account":{"nonce":1}}`))
+	}))
+	defer server.Close()
+
+	// Clients are shared between goroutines, e.g. by cache fetches and subscriptions, so configuring them mustn't race
+	client := &api.Client{Host: server.URL, TLS: &api.TLSConfig{}}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			account, err := client.GetAccount("erd1test")
+			assert.Nil(t, err)
+			assert.Equal(t, uint64(1), account.Nonce)
+		}()
+	}
+	wg.Wait()
+}
@@ -2,6 +2,7 @@ package api
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -36,30 +37,50 @@ type SendMultipleTransactionsResponse struct {
 
 // SendTransaction performs the actual HTTP request to send the transaction
 func (client *Client) SendTransaction(txData *TransactionData) (string, error) {
+	return client.SendTransactionContext(context.Background(), txData)
+}
+
+// SendTransactionContext performs the actual HTTP request to send the transaction using the supplied context
+func (client *Client) SendTransactionContext(ctx context.Context, txData *TransactionData) (string, error) {
 	client.Initialize()
 
-	url := fmt.Sprintf("%s/transaction/send", client.Host)
+	url := fmt.Sprintf("%s/transaction/send", client.host())
 
 	jsonData, err := json.Marshal(txData)
 	if err != nil {
 		return "", errors.Wrapf(err, "JSON Marshal")
 	}
 
-	req, err := http.NewRequest("POST", url, bytes.NewBuffer(jsonData))
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(jsonData))
 	if err != nil {
 		return "", errors.Wrapf(err, "HTTP NewRequest")
 	}
 
 	body, err := client.PerformRequest(url, req)
 	if err != nil {
+		client.recordTransactions(1, err)
+		client.invalidateRejectedSender(txData, err)
 		return "", errors.Wrapf(err, "Client PerformRequest")
 	}
 
 	var response SendTransactionResponse
-	json.Unmarshal([]byte(body), &response)
+	if err := json.Unmarshal(body, &response); err != nil {
+		client.recordTransactions(1, err)
+		return "", errors.Wrapf(err, "JSON Unmarshal")
+	}
 
 	if response.Error != "" {
-		return "", fmt.Errorf("Response error: %s", response.Error)
+		apiError := NewError(req, http.StatusOK, body)
+		client.recordTransactions(1, apiError)
+		client.invalidateRejectedSender(txData, apiError)
+		return "", apiError
+	}
+
+	client.recordTransactions(1, nil)
+
+	if client.Cache != nil {
+		client.Cache.InvalidateAccount(txData.Sender)
+		client.Cache.InvalidateAccount(txData.Receiver)
 	}
 
 	return response.TxHash, nil
@@ -67,31 +88,106 @@ func (client *Client) SendTransaction(txData *TransactionData) (string, error) {
 
 // SendMultipleTransactions performs the actual HTTP request to send the transactions
 func (client *Client) SendMultipleTransactions(txs []*TransactionData) (SendMultipleTransactionsResponse, error) {
+	return client.SendMultipleTransactionsContext(context.Background(), txs)
+}
+
+// SendMultipleTransactionsContext performs the actual HTTP request to send the transactions using the supplied context
+func (client *Client) SendMultipleTransactionsContext(ctx context.Context, txs []*TransactionData) (SendMultipleTransactionsResponse, error) {
 	client.Initialize()
 
-	url := fmt.Sprintf("%s/transaction/send-multiple", client.Host)
+	url := fmt.Sprintf("%s/transaction/send-multiple", client.host())
 
 	jsonData, err := json.Marshal(txs)
 	if err != nil {
 		return SendMultipleTransactionsResponse{}, errors.Wrapf(err, "JSON Marshal")
 	}
 
-	req, err := http.NewRequest("POST", url, bytes.NewBuffer(jsonData))
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(jsonData))
 	if err != nil {
 		return SendMultipleTransactionsResponse{}, errors.Wrapf(err, "HTTP NewRequest")
 	}
 
 	body, err := client.PerformRequest(url, req)
 	if err != nil {
+		client.recordTransactions(len(txs), err)
 		return SendMultipleTransactionsResponse{}, errors.Wrapf(err, "Client PerformRequest")
 	}
 
 	var response SendMultipleTransactionsResponse
-	json.Unmarshal([]byte(body), &response)
+	if err := json.Unmarshal(body, &response); err != nil {
+		client.recordTransactions(len(txs), err)
+		return SendMultipleTransactionsResponse{}, errors.Wrapf(err, "JSON Unmarshal")
+	}
 
 	if response.Error != "" {
-		return SendMultipleTransactionsResponse{}, fmt.Errorf("Response error: %s", response.Error)
+		apiError := NewError(req, http.StatusOK, body)
+		client.recordTransactions(len(txs), apiError)
+		return SendMultipleTransactionsResponse{}, apiError
+	}
+
+	client.recordTransactions(int(response.TxsSent), nil)
+	if unsent := len(txs) - int(response.TxsSent); unsent > 0 {
+		client.recordTransactions(unsent, NewError(req, http.StatusOK, body))
+	}
+
+	if client.Cache != nil {
+		for _, txData := range txs {
+			client.Cache.InvalidateAccount(txData.Sender)
+			client.Cache.InvalidateAccount(txData.Receiver)
+		}
 	}
 
 	return response, nil
 }
+
+// invalidateRejectedSender - drops the cached state of a sender whose transaction was rejected because of it,
+// so resyncing the nonce or balance doesn't read the stale cached account again
+func (client *Client) invalidateRejectedSender(txData *TransactionData, err error) {
+	if client.Cache == nil || txData == nil {
+		return
+	}
+
+	if errors.Is(err, ErrInvalidNonce) || errors.Is(err, ErrInsufficientFunds) {
+		client.Cache.InvalidateAccount(txData.Sender)
+	}
+}
+
+// TransactionCostResponse - API response when estimating the cost of a transaction
+type TransactionCostResponse struct {
+	TxGasUnits uint64 `json:"txGasUnits"`
+	Error      string `json:"error,omitempty"`
+}
+
+// EstimateTransactionCost asks the node how many gas units the transaction would consume
+func (client *Client) EstimateTransactionCost(txData *TransactionData) (uint64, error) {
+	return client.EstimateTransactionCostContext(context.Background(), txData)
+}
+
+// EstimateTransactionCostContext asks the node how many gas units the transaction would consume using the supplied context
+func (client *Client) EstimateTransactionCostContext(ctx context.Context, txData *TransactionData) (uint64, error) {
+	client.Initialize()
+
+	url := fmt.Sprintf("%s/transaction/cost", client.host())
+
+	jsonData, err := json.Marshal(txData)
+	if err != nil {
+		return 0, errors.Wrapf(err, "JSON Marshal")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return 0, errors.Wrapf(err, "HTTP NewRequest")
+	}
+
+	body, err := client.PerformRequest(url, req)
+	if err != nil {
+		return 0, errors.Wrapf(err, "Client PerformRequest")
+	}
+
+	var gasUnits uint64
+	if err := decodeResponse(body, "txGasUnits", &gasUnits); err != nil {
+		return 0, err
+	}
+
+	return gasUnits, nil
+}
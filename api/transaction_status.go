@@ -0,0 +1,216 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// TxStatusReceived - the transaction was received but not yet processed
+	TxStatusReceived TransactionStatus = "received"
+	// TxStatusPending - the transaction is waiting to be processed
+	TxStatusPending TransactionStatus = "pending"
+	// TxStatusPartiallyExecuted - the transaction was executed in the sender shard but not yet in the receiver shard
+	TxStatusPartiallyExecuted TransactionStatus = "partially-executed"
+	// TxStatusExecuted - the transaction was executed
+	TxStatusExecuted TransactionStatus = "executed"
+	// TxStatusSuccess - the transaction was executed successfully
+	TxStatusSuccess TransactionStatus = "success"
+	// TxStatusNotExecuted - the transaction was processed but not executed
+	TxStatusNotExecuted TransactionStatus = "not-executed"
+	// TxStatusFail - the transaction was executed but failed
+	TxStatusFail TransactionStatus = "fail"
+	// TxStatusInvalid - the transaction was deemed invalid
+	TxStatusInvalid TransactionStatus = "invalid"
+)
+
+const (
+	// DefaultPollInterval - how often WaitForTransaction checks the status of a transaction when no poll interval is given
+	DefaultPollInterval = 2 * time.Second
+)
+
+// TransactionStatus - the processing status of a transaction
+type TransactionStatus string
+
+// IsFinal - checks if the status won't change anymore
+func (status TransactionStatus) IsFinal() bool {
+	switch status {
+	case TxStatusExecuted, TxStatusSuccess, TxStatusNotExecuted, TxStatusFail, TxStatusInvalid:
+		return true
+	}
+
+	return false
+}
+
+// IsSuccessful - checks if the transaction was executed successfully
+func (status TransactionStatus) IsSuccessful() bool {
+	return status == TxStatusExecuted || status == TxStatusSuccess
+}
+
+// SmartContractResult - a smart contract result generated by a transaction
+type SmartContractResult struct {
+	Hash           string `json:"hash,omitempty"`
+	Nonce          uint64 `json:"nonce"`
+	Value          string `json:"value"`
+	Receiver       string `json:"receiver"`
+	Sender         string `json:"sender"`
+	Data           string `json:"data,omitempty"`
+	PrevTxHash     string `json:"prevTxHash,omitempty"`
+	OriginalTxHash string `json:"originalTxHash,omitempty"`
+	GasLimit       uint64 `json:"gasLimit"`
+	GasPrice       uint64 `json:"gasPrice"`
+	CallType       int    `json:"callType"`
+	ReturnMessage  string `json:"returnMessage,omitempty"`
+}
+
+// Receipt - the receipt generated for a transaction, e.g. for refunded gas
+type Receipt struct {
+	Value  string `json:"value"`
+	Sender string `json:"sender"`
+	Data   string `json:"data"`
+	TxHash string `json:"txHash"`
+}
+
+// TransactionInfo - a transaction as returned by the node, including its processing details
+type TransactionInfo struct {
+	Hash                 string                `json:"hash,omitempty"`
+	Type                 string                `json:"type"`
+	Nonce                uint64                `json:"nonce"`
+	Round                uint64                `json:"round"`
+	Epoch                uint32                `json:"epoch"`
+	Value                string                `json:"value"`
+	Receiver             string                `json:"receiver"`
+	Sender               string                `json:"sender"`
+	GasPrice             uint64                `json:"gasPrice"`
+	GasLimit             uint64                `json:"gasLimit"`
+	Data                 string                `json:"data,omitempty"`
+	Signature            string                `json:"signature,omitempty"`
+	Status               TransactionStatus     `json:"status"`
+	SourceShard          uint32                `json:"sourceShard"`
+	DestinationShard     uint32                `json:"destinationShard"`
+	BlockNonce           uint64                `json:"blockNonce"`
+	BlockHash            string                `json:"blockHash,omitempty"`
+	MiniBlockHash        string                `json:"miniblockHash,omitempty"`
+	MiniBlockType        string                `json:"miniblockType,omitempty"`
+	Timestamp            uint64                `json:"timestamp"`
+	SmartContractResults []SmartContractResult `json:"smartContractResults,omitempty"`
+	Receipt              *Receipt              `json:"receipt,omitempty"`
+}
+
+// GetTransaction fetches a transaction by its hash
+func (client *Client) GetTransaction(hash string) (TransactionInfo, error) {
+	return client.GetTransactionContext(context.Background(), hash)
+}
+
+// GetTransactionContext fetches a transaction by its hash using the supplied context
+func (client *Client) GetTransactionContext(ctx context.Context, hash string) (TransactionInfo, error) {
+	client.Initialize()
+
+	var transaction TransactionInfo
+
+	url := fmt.Sprintf("%s/transaction/%s?withResults=true", client.host(), hash)
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return transaction, err
+	}
+
+	body, err := client.PerformRequest(url, req)
+	if err != nil {
+		return transaction, err
+	}
+
+	if err := decodeResponse(body, "transaction", &transaction); err != nil {
+		return transaction, err
+	}
+
+	if transaction.Hash == "" {
+		transaction.Hash = hash
+	}
+
+	return transaction, nil
+}
+
+// GetTransactionStatus fetches the processing status of a transaction
+func (client *Client) GetTransactionStatus(hash string) (TransactionStatus, error) {
+	return client.GetTransactionStatusContext(context.Background(), hash)
+}
+
+// GetTransactionStatusContext fetches the processing status of a transaction using the supplied context
+func (client *Client) GetTransactionStatusContext(ctx context.Context, hash string) (TransactionStatus, error) {
+	client.Initialize()
+
+	url := fmt.Sprintf("%s/transaction/%s/status", client.host(), hash)
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return "", err
+	}
+
+	body, err := client.PerformRequest(url, req)
+	if err != nil {
+		return "", err
+	}
+
+	var status TransactionStatus
+	if err := decodeResponse(body, "status", &status); err != nil {
+		return "", err
+	}
+
+	return status, nil
+}
+
+// WaitForTransaction polls the status of a transaction until it's executed, failed or invalid
+// A timeout of 0 waits indefinitely, a poll interval of 0 uses DefaultPollInterval
+func (client *Client) WaitForTransaction(hash string, timeout time.Duration, pollInterval time.Duration) (TransactionInfo, error) {
+	return client.WaitForTransactionContext(context.Background(), hash, timeout, pollInterval)
+}
+
+// WaitForTransactionContext polls the status of a transaction until it's executed, failed or invalid using the supplied context
+// The final status is returned as part of the transaction - a failed transaction isn't considered an error
+func (client *Client) WaitForTransactionContext(ctx context.Context, hash string, timeout time.Duration, pollInterval time.Duration) (TransactionInfo, error) {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	if pollInterval <= 0 {
+		pollInterval = DefaultPollInterval
+	}
+
+	var lastStatus TransactionStatus
+
+	for {
+		status, err := client.GetTransactionStatusContext(ctx, hash)
+		switch {
+		case err == nil:
+			lastStatus = status
+		case errors.Is(err, ErrTransactionNotFound):
+			// The transaction might not have reached the node yet
+		case ctx.Err() != nil:
+			return TransactionInfo{Hash: hash, Status: lastStatus}, errors.Wrapf(ctx.Err(), "waiting for transaction %s", hash)
+		default:
+			return TransactionInfo{Hash: hash, Status: lastStatus}, err
+		}
+
+		if lastStatus.IsFinal() {
+			transaction, err := client.GetTransactionContext(ctx, hash)
+			if err != nil {
+				return TransactionInfo{Hash: hash, Status: lastStatus}, err
+			}
+
+			if transaction.Status == "" {
+				transaction.Status = lastStatus
+			}
+
+			return transaction, nil
+		}
+
+		if err := sleepContext(ctx, pollInterval); err != nil {
+			return TransactionInfo{Hash: hash, Status: lastStatus}, errors.Wrapf(err, "waiting for transaction %s", hash)
+		}
+	}
+}
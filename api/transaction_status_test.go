@@ -0,0 +1,64 @@
+package api_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/SebastianJ/elrond-sdk/api"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWaitForTransaction(t *testing.T) {
+	t.Parallel()
+
+	var statusRequests int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/status"):
+			switch atomic.AddInt32(&statusRequests, 1) {
+			case 1:
+				w.WriteHeader(http.StatusInternalServerError)
+				w.Write([]byte(`{"error":"transaction getting failed"}`))
+			case 2:
+				w.Write([]byte(`{"status":"pending"}`))
+			default:
+				w.Write([]byte(`{"status":"executed"}`))
+			}
+		default:
+			w.Write([]byte(`{"data":{"transaction":{"type":"normal","nonce":3,"value":"100","blockNonce":42,"smartContractResults":[{"hash":"abc","value":"1"}]}},"code":"successful"}`))
+		}
+	}))
+	defer server.Close()
+
+	client := api.Client{Host: server.URL}
+	transaction, err := client.WaitForTransaction("txhash", time.Second, 10*time.Millisecond)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "txhash", transaction.Hash)
+	assert.Equal(t, api.TxStatusExecuted, transaction.Status)
+	assert.True(t, transaction.Status.IsSuccessful())
+	assert.Equal(t, uint64(42), transaction.BlockNonce)
+	assert.Len(t, transaction.SmartContractResults, 1)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&statusRequests))
+}
+
+func TestWaitForTransactionTimeout(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"status":"pending"}`))
+	}))
+	defer server.Close()
+
+	client := api.Client{Host: server.URL}
+	transaction, err := client.WaitForTransaction("txhash", 50*time.Millisecond, 10*time.Millisecond)
+
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	assert.Equal(t, api.TxStatusPending, transaction.Status)
+}
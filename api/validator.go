@@ -0,0 +1,164 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Heartbeat - the heartbeat status of a node, as seen by the queried node
+type Heartbeat struct {
+	PublicKey       string    `json:"publicKey"`
+	TimeStamp       time.Time `json:"timeStamp"`
+	MaxInactiveTime string    `json:"maxInactiveTime"`
+	IsActive        bool      `json:"isActive"`
+	ReceivedShardID uint32    `json:"receivedShardID"`
+	ComputedShardID uint32    `json:"computedShardID"`
+	TotalUpTime     int64     `json:"totalUpTimeSec"`
+	TotalDownTime   int64     `json:"totalDownTimeSec"`
+	VersionNumber   string    `json:"versionNumber"`
+	NodeDisplayName string    `json:"nodeDisplayName"`
+	Identity        string    `json:"identity"`
+	PeerType        string    `json:"peerType"`
+}
+
+// ValidatorStatistics - the rating and block signing statistics of a validator
+type ValidatorStatistics struct {
+	TempRating               float32 `json:"tempRating"`
+	NumLeaderSuccess         uint32  `json:"numLeaderSuccess"`
+	NumLeaderFailure         uint32  `json:"numLeaderFailure"`
+	NumValidatorSuccess      uint32  `json:"numValidatorSuccess"`
+	NumValidatorFailure      uint32  `json:"numValidatorFailure"`
+	Rating                   float32 `json:"rating"`
+	RatingModifier           float32 `json:"ratingModifier"`
+	TotalNumLeaderSuccess    uint32  `json:"totalNumLeaderSuccess"`
+	TotalNumLeaderFailure    uint32  `json:"totalNumLeaderFailure"`
+	TotalNumValidatorSuccess uint32  `json:"totalNumValidatorSuccess"`
+	TotalNumValidatorFailure uint32  `json:"totalNumValidatorFailure"`
+	ShardID                  uint32  `json:"shardId"`
+	ValidatorStatus          string  `json:"validatorStatus"`
+}
+
+// ValidatorInfo - the heartbeat and statistics of a single BLS key, either may be nil if the node doesn't know the key
+type ValidatorInfo struct {
+	PublicKey  string
+	Heartbeat  *Heartbeat
+	Statistics *ValidatorStatistics
+}
+
+// GetHeartbeatStatus fetches the heartbeats of all nodes known to the queried node
+func (client *Client) GetHeartbeatStatus() ([]Heartbeat, error) {
+	return client.GetHeartbeatStatusContext(context.Background())
+}
+
+// GetHeartbeatStatusContext fetches the heartbeats of all nodes known to the queried node using the supplied context
+func (client *Client) GetHeartbeatStatusContext(ctx context.Context) ([]Heartbeat, error) {
+	client.Initialize()
+
+	url := fmt.Sprintf("%s/node/heartbeatstatus", client.host())
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := client.PerformRequest(url, req)
+	if err != nil {
+		return nil, err
+	}
+
+	// Older nodes return the heartbeats as message, newer nodes as heartbeats
+	var heartbeats []Heartbeat
+	if err := decodeResponse(body, "message", &heartbeats); err != nil {
+		if err := decodeResponse(body, "heartbeats", &heartbeats); err != nil {
+			return nil, err
+		}
+	}
+
+	return heartbeats, nil
+}
+
+// GetValidatorStatistics fetches the statistics of all validators, keyed by their hex encoded BLS public key
+func (client *Client) GetValidatorStatistics() (map[string]ValidatorStatistics, error) {
+	return client.GetValidatorStatisticsContext(context.Background())
+}
+
+// GetValidatorStatisticsContext fetches the statistics of all validators using the supplied context
+func (client *Client) GetValidatorStatisticsContext(ctx context.Context) (map[string]ValidatorStatistics, error) {
+	client.Initialize()
+
+	url := fmt.Sprintf("%s/validator/statistics", client.host())
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := client.PerformRequest(url, req)
+	if err != nil {
+		return nil, err
+	}
+
+	statistics := make(map[string]ValidatorStatistics)
+	if err := decodeResponse(body, "statistics", &statistics); err != nil {
+		return nil, err
+	}
+
+	return statistics, nil
+}
+
+// GetValidatorInfo looks up the heartbeat and statistics of the given hex encoded BLS public keys
+func (client *Client) GetValidatorInfo(publicKeys []string) (map[string]ValidatorInfo, error) {
+	return client.GetValidatorInfoContext(context.Background(), publicKeys)
+}
+
+// GetValidatorInfoContext looks up the heartbeat and statistics of the given hex encoded BLS public keys using the supplied context
+func (client *Client) GetValidatorInfoContext(ctx context.Context, publicKeys []string) (map[string]ValidatorInfo, error) {
+	heartbeats, err := client.GetHeartbeatStatusContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	statistics, err := client.GetValidatorStatisticsContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	heartbeatsByKey := make(map[string]*Heartbeat, len(heartbeats))
+	for index := range heartbeats {
+		heartbeatsByKey[heartbeats[index].PublicKey] = &heartbeats[index]
+	}
+
+	validators := make(map[string]ValidatorInfo, len(publicKeys))
+	for _, publicKey := range publicKeys {
+		validator := ValidatorInfo{
+			PublicKey: publicKey,
+			Heartbeat: heartbeatsByKey[publicKey],
+		}
+
+		if stats, ok := statistics[publicKey]; ok {
+			validator.Statistics = &stats
+		}
+
+		validators[publicKey] = validator
+	}
+
+	return validators, nil
+}
+
+// Online - checks if the validator is currently sending heartbeats
+func (validator ValidatorInfo) Online() bool {
+	return validator.Heartbeat != nil && validator.Heartbeat.IsActive
+}
+
+// ShardID - the shard the validator is assigned to, preferring the statistics over the heartbeat
+func (validator ValidatorInfo) ShardID() uint32 {
+	if validator.Statistics != nil {
+		return validator.Statistics.ShardID
+	}
+
+	if validator.Heartbeat != nil {
+		return validator.Heartbeat.ComputedShardID
+	}
+
+	return 0
+}
@@ -0,0 +1,96 @@
+package api_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/SebastianJ/elrond-sdk/api"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	onlineKey  = "c4ac6bc7b77126a793d3239f8a13c24a"
+	offlineKey = "5a8973ddd15907de5ca382c60fe3b3f6"
+	unknownKey = "b827f28da78678d905f2a79512ae6724"
+)
+
+func newValidatorServer(heartbeatsKey string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/node/heartbeatstatus":
+			w.Write([]byte(`{"data":{"` + heartbeatsKey + `":[
+				{"publicKey":"` + onlineKey + `","timeStamp":"2020-06-22T12:00:00Z","isActive":true,"computedShardID":1,"nodeDisplayName":"online","peerType":"eligible"},
+				{"publicKey":"` + offlineKey + `","isActive":false,"computedShardID":2,"nodeDisplayName":"offline"}
+			]},"code":"successful"}`))
+		case "/validator/statistics":
+			w.Write([]byte(`{"statistics":{
+				"` + onlineKey + `":{"rating":99.5,"tempRating":98,"numLeaderSuccess":3,"shardId":1,"validatorStatus":"eligible"}
+			}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestGetHeartbeatStatus(t *testing.T) {
+	t.Parallel()
+
+	// Older nodes return the heartbeats as message, newer nodes as heartbeats
+	for _, key := range []string{"message", "heartbeats"} {
+		server := newValidatorServer(key)
+
+		client := api.Client{Host: server.URL}
+		heartbeats, err := client.GetHeartbeatStatus()
+		assert.Nil(t, err)
+		assert.Len(t, heartbeats, 2)
+		assert.Equal(t, onlineKey, heartbeats[0].PublicKey)
+		assert.True(t, heartbeats[0].IsActive)
+		assert.Equal(t, 2020, heartbeats[0].TimeStamp.Year())
+		assert.Equal(t, "offline", heartbeats[1].NodeDisplayName)
+
+		server.Close()
+	}
+}
+
+func TestGetValidatorStatistics(t *testing.T) {
+	t.Parallel()
+
+	server := newValidatorServer("heartbeats")
+	defer server.Close()
+
+	client := api.Client{Host: server.URL}
+	statistics, err := client.GetValidatorStatistics()
+	assert.Nil(t, err)
+	assert.Len(t, statistics, 1)
+	assert.Equal(t, float32(99.5), statistics[onlineKey].Rating)
+	assert.Equal(t, uint32(3), statistics[onlineKey].NumLeaderSuccess)
+	assert.Equal(t, "eligible", statistics[onlineKey].ValidatorStatus)
+}
+
+func TestGetValidatorInfo(t *testing.T) {
+	t.Parallel()
+
+	server := newValidatorServer("heartbeats")
+	defer server.Close()
+
+	client := api.Client{Host: server.URL}
+	validators, err := client.GetValidatorInfo([]string{onlineKey, offlineKey, unknownKey})
+	assert.Nil(t, err)
+	assert.Len(t, validators, 3)
+
+	online := validators[onlineKey]
+	assert.True(t, online.Online())
+	assert.Equal(t, uint32(1), online.ShardID())
+	assert.NotNil(t, online.Statistics)
+
+	offline := validators[offlineKey]
+	assert.False(t, offline.Online())
+	assert.Nil(t, offline.Statistics)
+	assert.Equal(t, uint32(2), offline.ShardID())
+
+	unknown := validators[unknownKey]
+	assert.False(t, unknown.Online())
+	assert.Nil(t, unknown.Heartbeat)
+	assert.Equal(t, uint32(0), unknown.ShardID())
+}
@@ -0,0 +1,189 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strconv"
+
+	"github.com/SebastianJ/elrond-sdk/utils"
+	"github.com/pkg/errors"
+)
+
+const (
+	// ReturnCodeOk - the VM query executed successfully
+	ReturnCodeOk ReturnCode = "ok"
+)
+
+var (
+	// ErrVMQueryFailed - the VM executed the query but returned a non ok return code
+	ErrVMQueryFailed = errors.New("vm query failed")
+	// ErrNoReturnData - the requested return data index doesn't exist
+	ErrNoReturnData = errors.New("no return data at the requested index")
+
+	returnCodes = []ReturnCode{
+		ReturnCodeOk,
+		"function not found",
+		"wrong signature for function",
+		"contract not found",
+		"user error",
+		"out of gas",
+		"account collision",
+		"out of funds",
+		"call stack overflow",
+		"contract invalid",
+		"execution failed",
+		"upgrade failed",
+	}
+)
+
+// ReturnCode - the return code of a VM execution
+type ReturnCode string
+
+// UnmarshalJSON - accepts both the numeric and the textual form of a return code
+func (code *ReturnCode) UnmarshalJSON(data []byte) error {
+	var text string
+	if err := json.Unmarshal(data, &text); err == nil {
+		*code = ReturnCode(text)
+		return nil
+	}
+
+	var numeric int
+	if err := json.Unmarshal(data, &numeric); err != nil {
+		return err
+	}
+
+	if numeric >= 0 && numeric < len(returnCodes) {
+		*code = returnCodes[numeric]
+	} else {
+		*code = ReturnCode(strconv.Itoa(numeric))
+	}
+
+	return nil
+}
+
+// VMQuery - a read only call to a smart contract function
+type VMQuery struct {
+	ScAddress string `json:"scAddress"`
+	FuncName  string `json:"funcName"`
+	// Caller is optional and is the bech32 address the query is executed on behalf of
+	Caller string `json:"caller,omitempty"`
+	// Args are the hex encoded function arguments
+	Args []string `json:"args"`
+}
+
+// VMOutput - the result of a VM query
+type VMOutput struct {
+	ReturnData    [][]byte   `json:"returnData"`
+	ReturnCode    ReturnCode `json:"returnCode"`
+	ReturnMessage string     `json:"returnMessage"`
+	GasRemaining  uint64     `json:"gasRemaining"`
+}
+
+// QueryVM executes a read only smart contract query
+func (client *Client) QueryVM(query VMQuery) (VMOutput, error) {
+	return client.QueryVMContext(context.Background(), query)
+}
+
+// QueryVMContext executes a read only smart contract query using the supplied context
+// A non ok return code returns the output together with an error wrapping ErrVMQueryFailed
+func (client *Client) QueryVMContext(ctx context.Context, query VMQuery) (VMOutput, error) {
+	client.Initialize()
+
+	var output VMOutput
+
+	if query.Args == nil {
+		query.Args = []string{}
+	}
+
+	url := fmt.Sprintf("%s/vm-values/query", client.host())
+
+	jsonData, err := json.Marshal(query)
+	if err != nil {
+		return output, errors.Wrapf(err, "JSON Marshal")
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return output, errors.Wrapf(err, "HTTP NewRequest")
+	}
+
+	body, err := client.PerformRequest(url, req)
+	if err != nil {
+		return output, errors.Wrapf(err, "Client PerformRequest")
+	}
+
+	var data json.RawMessage
+	if err := decodeResponse(body, "data", &data); err != nil {
+		return output, err
+	}
+
+	// Newer nodes wrap the output in an additional data field
+	var nested struct {
+		Data *VMOutput `json:"data"`
+	}
+	if err := json.Unmarshal(data, &nested); err == nil && nested.Data != nil {
+		output = *nested.Data
+	} else if err := json.Unmarshal(data, &output); err != nil {
+		return output, errors.Wrapf(err, "JSON Unmarshal")
+	}
+
+	if output.ReturnCode != "" && output.ReturnCode != ReturnCodeOk {
+		return output, errors.Wrapf(ErrVMQueryFailed, "%s: %s", output.ReturnCode, output.ReturnMessage)
+	}
+
+	return output, nil
+}
+
+// AsBytes - returns the raw return data at the given index
+func (output VMOutput) AsBytes(index int) ([]byte, error) {
+	if index < 0 || index >= len(output.ReturnData) {
+		return nil, ErrNoReturnData
+	}
+
+	return output.ReturnData[index], nil
+}
+
+// AsHex - returns the return data at the given index hex encoded
+func (output VMOutput) AsHex(index int) (string, error) {
+	data, err := output.AsBytes(index)
+	if err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(data), nil
+}
+
+// AsBigInt - returns the return data at the given index decoded as an unsigned big integer
+func (output VMOutput) AsBigInt(index int) (*big.Int, error) {
+	data, err := output.AsBytes(index)
+	if err != nil {
+		return nil, err
+	}
+
+	return new(big.Int).SetBytes(data), nil
+}
+
+// AsString - returns the return data at the given index decoded as a string
+func (output VMOutput) AsString(index int) (string, error) {
+	data, err := output.AsBytes(index)
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
+
+// AsAddress - returns the return data at the given index decoded as a bech32 address
+func (output VMOutput) AsAddress(index int) (string, error) {
+	data, err := output.AsHex(index)
+	if err != nil {
+		return "", err
+	}
+
+	return utils.PublicKeyToBech32(data)
+}
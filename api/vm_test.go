@@ -0,0 +1,69 @@
+package api_test
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/SebastianJ/elrond-sdk/api"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQueryVM(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+
+		var query api.VMQuery
+		assert.Nil(t, json.Unmarshal(body, &query))
+		assert.Equal(t, "/vm-values/query", r.URL.Path)
+		assert.Equal(t, "getTotalStaked", query.FuncName)
+
+		// ReturnData: 1000000, "hello", 32 byte public key
+		w.Write([]byte(`{"data":{"ReturnData":["D0JA","aGVsbG8=","fI8Nsz89vd3Okb8evK2OhjjZfWgHhTHJEvbDUzSNzno="],"ReturnCode":0,"ReturnMessage":""}}`))
+	}))
+	defer server.Close()
+
+	client := api.Client{Host: server.URL}
+	output, err := client.QueryVM(api.VMQuery{ScAddress: "erd1qqqqqqqqqqqqqqqpqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqqplllst77y4l", FuncName: "getTotalStaked"})
+	assert.Nil(t, err)
+	assert.Equal(t, api.ReturnCodeOk, output.ReturnCode)
+
+	amount, err := output.AsBigInt(0)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1000000), amount.Int64())
+
+	hexData, err := output.AsHex(0)
+	assert.Nil(t, err)
+	assert.Equal(t, "0f4240", hexData)
+
+	text, err := output.AsString(1)
+	assert.Nil(t, err)
+	assert.Equal(t, "hello", text)
+
+	address, err := output.AsAddress(2)
+	assert.Nil(t, err)
+	assert.Equal(t, "erd10j8smvel8k7amn53hu0tetvwscudjltgq7znrjgj7mp4xdydeeaqajjvwy", address)
+
+	_, err = output.AsBytes(3)
+	assert.Equal(t, api.ErrNoReturnData, err)
+}
+
+func TestQueryVMFailure(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"data":{"data":{"returnData":null,"returnCode":"function not found","returnMessage":"invalid function"}},"code":"successful"}`))
+	}))
+	defer server.Close()
+
+	client := api.Client{Host: server.URL}
+	output, err := client.QueryVM(api.VMQuery{FuncName: "missing"})
+
+	assert.True(t, errors.Is(err, api.ErrVMQueryFailed))
+	assert.Equal(t, api.ReturnCode("function not found"), output.ReturnCode)
+}
@@ -0,0 +1,237 @@
+// Package logging defines the structured logger used throughout the SDK
+//
+// The SDK is silent by default. Install a logger using SetDefault, or per client using api.Client.Logger:
+//
+//	logging.SetDefault(logging.NewJSONLogger(os.Stderr, logging.LevelInfo))
+package logging
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Level - the severity of a log entry
+type Level int
+
+// Log levels, in increasing order of severity
+const (
+	LevelDebug Level = iota
+	LevelInfo
+	LevelWarn
+	LevelError
+)
+
+// Redacted - the value logged in place of secrets
+const Redacted = "[REDACTED]"
+
+// redactedURLPart - the value used in place of secrets in urls, without characters requiring escaping
+const redactedURLPart = "REDACTED"
+
+var (
+	defaultLogger Logger = Nop
+	defaultMutex  sync.RWMutex
+
+	// sensitiveKeys - field keys whose values are always redacted by the loggers in this package
+	sensitiveKeys = []string{
+		"password", "passphrase", "secret", "privatekey", "private_key", "mnemonic", "seed",
+		"authorization", "apikey", "api_key", "api-key", "accesstoken", "access_token", "bearer", "cookie",
+	}
+)
+
+// Field - a key/value pair attached to a log entry
+type Field struct {
+	Key   string
+	Value interface{}
+}
+
+// Logger - a structured logger, implementations have to be safe for concurrent use
+type Logger interface {
+	Debug(message string, fields ...Field)
+	Info(message string, fields ...Field)
+	Warn(message string, fields ...Field)
+	Error(message string, fields ...Field)
+}
+
+// F - creates a field
+func F(key string, value interface{}) Field {
+	return Field{Key: key, Value: value}
+}
+
+// Err - creates an error field
+func Err(err error) Field {
+	if err == nil {
+		return Field{Key: "error", Value: nil}
+	}
+
+	return Field{Key: "error", Value: err.Error()}
+}
+
+// Secret - creates a field whose value is never logged
+func Secret(key string) Field {
+	return Field{Key: key, Value: Redacted}
+}
+
+// URL - creates a field for a url with its user info and sensitive query parameters redacted
+func URL(key string, rawURL string) Field {
+	return Field{Key: key, Value: RedactURL(rawURL)}
+}
+
+// RedactURL - removes credentials and sensitive query parameters from a url
+func RedactURL(rawURL string) string {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return Redacted
+	}
+
+	if parsed.User != nil {
+		parsed.User = url.User(redactedURLPart)
+	}
+
+	query := parsed.Query()
+	redacted := false
+	for key := range query {
+		if IsSensitive(key) {
+			query.Set(key, redactedURLPart)
+			redacted = true
+		}
+	}
+	if redacted {
+		parsed.RawQuery = query.Encode()
+	}
+
+	return parsed.String()
+}
+
+// Default - the logger used by the SDK unless a more specific one is configured
+func Default() Logger {
+	defaultMutex.RLock()
+	defer defaultMutex.RUnlock()
+
+	return defaultLogger
+}
+
+// SetDefault - sets the logger used by the SDK, nil restores the silent default
+func SetDefault(logger Logger) {
+	defaultMutex.Lock()
+	defer defaultMutex.Unlock()
+
+	if logger == nil {
+		logger = Nop
+	}
+	defaultLogger = logger
+}
+
+// Nop - a logger discarding all entries
+var Nop Logger = nopLogger{}
+
+type nopLogger struct{}
+
+func (nopLogger) Debug(string, ...Field) {}
+func (nopLogger) Info(string, ...Field)  {}
+func (nopLogger) Warn(string, ...Field)  {}
+func (nopLogger) Error(string, ...Field) {}
+
+// JSONLogger - writes entries at or above its level as JSON lines, redacting sensitive fields
+type JSONLogger struct {
+	level  Level
+	mutex  sync.Mutex
+	writer io.Writer
+}
+
+// NewJSONLogger - creates a logger writing JSON lines to the writer
+func NewJSONLogger(writer io.Writer, level Level) *JSONLogger {
+	return &JSONLogger{level: level, writer: writer}
+}
+
+// Debug - logs a debug entry
+func (logger *JSONLogger) Debug(message string, fields ...Field) {
+	logger.log(LevelDebug, message, fields)
+}
+
+// Info - logs an info entry
+func (logger *JSONLogger) Info(message string, fields ...Field) {
+	logger.log(LevelInfo, message, fields)
+}
+
+// Warn - logs a warning entry
+func (logger *JSONLogger) Warn(message string, fields ...Field) {
+	logger.log(LevelWarn, message, fields)
+}
+
+// Error - logs an error entry
+func (logger *JSONLogger) Error(message string, fields ...Field) {
+	logger.log(LevelError, message, fields)
+}
+
+func (logger *JSONLogger) log(level Level, message string, fields []Field) {
+	if level < logger.level {
+		return
+	}
+
+	entry := make(map[string]interface{}, len(fields)+3)
+	for _, field := range fields {
+		entry[field.Key] = sanitize(field)
+	}
+	entry["time"] = time.Now().UTC().Format(time.RFC3339Nano)
+	entry["level"] = level.String()
+	entry["message"] = message
+
+	encoded, err := json.Marshal(entry)
+	if err != nil {
+		encoded, _ = json.Marshal(map[string]string{"level": level.String(), "message": message, "error": err.Error()})
+	}
+
+	logger.mutex.Lock()
+	defer logger.mutex.Unlock()
+
+	logger.writer.Write(append(encoded, '\n'))
+}
+
+// String - the name of the level
+func (level Level) String() string {
+	switch level {
+	case LevelDebug:
+		return "debug"
+	case LevelInfo:
+		return "info"
+	case LevelWarn:
+		return "warn"
+	case LevelError:
+		return "error"
+	}
+
+	return fmt.Sprintf("level(%d)", int(level))
+}
+
+// sanitize - the loggable value of a field, redacting values of sensitive keys
+func sanitize(field Field) interface{} {
+	if IsSensitive(field.Key) {
+		return Redacted
+	}
+
+	switch value := field.Value.(type) {
+	case error:
+		return value.Error()
+	case fmt.Stringer:
+		return value.String()
+	}
+
+	return field.Value
+}
+
+// IsSensitive - checks if values stored under a key, e.g. a field, query parameter or header name, have to be redacted
+func IsSensitive(key string) bool {
+	key = strings.ToLower(key)
+	for _, sensitive := range sensitiveKeys {
+		if strings.Contains(key, sensitive) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -1,9 +1,12 @@
 package transactions
 
 import (
+	"context"
+	"math"
 	"math/big"
 	"strconv"
 
+	"github.com/SebastianJ/elrond-sdk/api"
 	"github.com/SebastianJ/elrond-sdk/config"
 )
 
@@ -21,6 +24,10 @@ type GasParams struct {
 	GasPrice       uint64
 	GasLimit       uint64
 	GasPerDataByte uint64
+	// EstimateGasLimit replaces the gas limit with the node's estimate for the transaction
+	EstimateGasLimit bool
+	// GasLimitMargin is the safety margin added on top of an estimated gas limit, e.g. 0.1 for 10%, negative margins count as 0
+	GasLimitMargin float64
 }
 
 // ParseGasSettings - parse relevant gas settings from economics.toml
@@ -54,6 +61,59 @@ func ParseGasSettings(configPath string) (GasParams, error) {
 	return gasParams, nil
 }
 
+// FetchGasSettings - fetch relevant gas settings from the network config exposed by a node
+func FetchGasSettings(client api.Client) (GasParams, error) {
+	return FetchGasSettingsContext(context.Background(), client)
+}
+
+// FetchGasSettingsContext - fetch relevant gas settings from the network config exposed by a node using the supplied context
+func FetchGasSettingsContext(ctx context.Context, client api.Client) (GasParams, error) {
+	networkConfig, err := client.GetNetworkConfigContext(ctx)
+	if err != nil {
+		return DefaultGasParams, err
+	}
+
+	return NewGasParamsFromNetworkConfig(networkConfig), nil
+}
+
+// NewGasParamsFromNetworkConfig - builds gas params from a network config, using the defaults for missing values
+func NewGasParamsFromNetworkConfig(networkConfig api.NetworkConfig) GasParams {
+	gasParams := DefaultGasParams
+
+	if networkConfig.MinGasPrice > 0 {
+		gasParams.GasPrice = networkConfig.MinGasPrice
+	}
+
+	if networkConfig.MinGasLimit > 0 {
+		gasParams.GasLimit = networkConfig.MinGasLimit
+	}
+
+	if networkConfig.GasPerDataByte > 0 {
+		gasParams.GasPerDataByte = networkConfig.GasPerDataByte
+	}
+
+	return gasParams
+}
+
+// NewGasParamsFromNetwork - builds gas params from a network profile, using the defaults for missing values
+func NewGasParamsFromNetwork(profile api.NetworkProfile) GasParams {
+	gasParams := DefaultGasParams
+
+	if profile.GasPrice > 0 {
+		gasParams.GasPrice = profile.GasPrice
+	}
+
+	if profile.GasLimit > 0 {
+		gasParams.GasLimit = profile.GasLimit
+	}
+
+	if profile.GasPerDataByte > 0 {
+		gasParams.GasPerDataByte = profile.GasPerDataByte
+	}
+
+	return gasParams
+}
+
 // UpdateGasLimit - update gas limit based on tx data
 func (gasParams *GasParams) UpdateGasLimit(data string) {
 	if len(data) > 0 {
@@ -61,6 +121,13 @@ func (gasParams *GasParams) UpdateGasLimit(data string) {
 	}
 }
 
+// ApplyGasEstimate - sets the gas limit to an estimate from the node plus the configured safety margin
+// A negative margin is treated as 0, the gas limit is never set below the estimate
+func (gasParams *GasParams) ApplyGasEstimate(estimate uint64) {
+	margin := uint64(math.Ceil(float64(estimate) * math.Max(gasParams.GasLimitMargin, 0)))
+	gasParams.GasLimit = estimate + margin
+}
+
 // CalculateTotalGasCost - calculates the total gas cost for a given transaction
 func (gasParams *GasParams) CalculateTotalGasCost() *big.Int {
 	bigGasPrice := new(big.Int).SetUint64(gasParams.GasPrice)
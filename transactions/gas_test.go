@@ -0,0 +1,102 @@
+package transactions_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/SebastianJ/elrond-sdk/api"
+	"github.com/SebastianJ/elrond-sdk/transactions"
+	sdkWallet "github.com/SebastianJ/elrond-sdk/wallet"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFetchGasSettings(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/network/config", r.URL.Path)
+		w.Write([]byte(`{"config":{"erd_chain_id":"1","erd_min_gas_price":1000000000,"erd_min_gas_limit":70000,"erd_gas_per_data_byte":1500,"erd_num_shards_without_meta":3,"erd_round_duration":6000}}`))
+	}))
+	defer server.Close()
+
+	client := api.Client{Host: server.URL}
+
+	gasParams, err := transactions.FetchGasSettings(client)
+	assert.Nil(t, err)
+	assert.Equal(t, transactions.GasParams{GasPrice: 1000000000, GasLimit: 70000, GasPerDataByte: 1500}, gasParams)
+
+	networkConfig, err := client.GetNetworkConfig()
+	assert.Nil(t, err)
+	assert.Equal(t, "1", networkConfig.ChainID)
+	assert.Equal(t, uint32(3), networkConfig.NumShards)
+	assert.Equal(t, int64(6), int64(networkConfig.RoundDurationTime().Seconds()))
+}
+
+func TestNewGasParamsFromPartialNetworkConfig(t *testing.T) {
+	t.Parallel()
+
+	gasParams := transactions.NewGasParamsFromNetworkConfig(api.NetworkConfig{MinGasPrice: 1000000000})
+
+	assert.Equal(t, uint64(1000000000), gasParams.GasPrice)
+	assert.Equal(t, transactions.DefaultGasParams.GasLimit, gasParams.GasLimit)
+	assert.Equal(t, transactions.DefaultGasParams.GasPerDataByte, gasParams.GasPerDataByte)
+}
+
+func TestNewGasParamsFromNetwork(t *testing.T) {
+	t.Parallel()
+
+	profile, err := api.LookupNetwork(api.Testnet)
+	assert.Nil(t, err)
+
+	gasParams := transactions.NewGasParamsFromNetwork(profile)
+	assert.Equal(t, transactions.GasParams{GasPrice: 1000000000, GasLimit: 50000, GasPerDataByte: 1500}, gasParams)
+
+	// Missing values fall back to the defaults
+	gasParams = transactions.NewGasParamsFromNetwork(api.NetworkProfile{GasPrice: 2000000000})
+	assert.Equal(t, uint64(2000000000), gasParams.GasPrice)
+	assert.Equal(t, transactions.DefaultGasParams.GasLimit, gasParams.GasLimit)
+}
+
+func TestApplyGasEstimate(t *testing.T) {
+	t.Parallel()
+
+	gasParams := transactions.GasParams{GasLimit: 50000, GasLimitMargin: 0.1}
+	gasParams.ApplyGasEstimate(6000000)
+
+	assert.Equal(t, uint64(6600000), gasParams.GasLimit)
+
+	// Negative margins would underprice the transaction or wrap around
+	for _, margin := range []float64{-0.5, -1.5} {
+		gasParams = transactions.GasParams{GasLimit: 50000, GasLimitMargin: margin}
+		gasParams.ApplyGasEstimate(6000000)
+
+		assert.Equal(t, uint64(6000000), gasParams.GasLimit, "margin %v", margin)
+	}
+}
+
+func TestGenerateTransactionWithGasEstimate(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/transaction/cost":
+			w.Write([]byte(`{"txGasUnits":5000000}`))
+		default:
+			w.Write([]byte(`{"account":{"nonce":7,"balance":"0"}}`))
+		}
+	}))
+	defer server.Close()
+
+	wallet, err := sdkWallet.Generate()
+	assert.Nil(t, err)
+
+	gasParams := transactions.DefaultGasParams
+	gasParams.EstimateGasLimit = true
+	gasParams.GasLimitMargin = 0.2
+
+	tx, err := transactions.GenerateTransaction(wallet, wallet.Address, 1, false, -1, "claim", gasParams, api.Client{Host: server.URL})
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(6000000), tx.APIData.GasLimit)
+	assert.Equal(t, uint64(7), tx.APIData.Nonce)
+}
@@ -1,9 +1,10 @@
 package transactions
 
 import (
+	"context"
 	"encoding/hex"
+	"errors"
 	"math/big"
-	"strings"
 	"time"
 
 	"github.com/ElrondNetwork/elrond-go/core"
@@ -11,6 +12,7 @@ import (
 	"github.com/ElrondNetwork/elrond-go/hashing/blake2b"
 	"github.com/ElrondNetwork/elrond-go/marshal"
 	"github.com/SebastianJ/elrond-sdk/api"
+	"github.com/SebastianJ/elrond-sdk/logging"
 	"github.com/SebastianJ/elrond-sdk/utils"
 	sdkWallet "github.com/SebastianJ/elrond-sdk/wallet"
 )
@@ -41,24 +43,57 @@ func SendTransaction(
 	gasParams GasParams,
 	client api.Client,
 ) (Transaction, string, error) {
-	tx, err := GenerateAndSignTransaction(wallet, receiver, amount, sendMaximumAmount, nonce, txData, gasParams, client)
-	if err != nil {
-		return Transaction{}, "", err
+	return SendTransactionContext(context.Background(), wallet, receiver, amount, sendMaximumAmount, nonce, txData, gasParams, client)
+}
+
+// SendTransactionContext - generates and broadcasts a transaction to the blockchain using the supplied context
+// Transactions rejected due to an invalid nonce are regenerated with a fresh nonce, bounded by the client's RetryPolicy
+func SendTransactionContext(
+	ctx context.Context,
+	wallet sdkWallet.Wallet,
+	receiver string,
+	amount float64,
+	sendMaximumAmount bool,
+	nonce int64,
+	txData string,
+	gasParams GasParams,
+	client api.Client,
+) (Transaction, string, error) {
+	policy := client.RetryPolicy
+	if policy == nil {
+		policy = &api.DefaultRetryPolicy
 	}
 
-	txHexHash, txError := client.SendTransaction(tx.APIData)
+	for attempt := 1; ; attempt++ {
+		tx, err := GenerateAndSignTransactionContext(ctx, wallet, receiver, amount, sendMaximumAmount, nonce, txData, gasParams, client)
+		if err != nil {
+			return Transaction{}, "", err
+		}
 
-	if txError != nil {
-		// If we've sent an invalid nonce - sleep 3 seconds and then retry again using a fresh nonce
-		if strings.Contains(txError.Error(), "transaction generation failed: invalid nonce") {
-			time.Sleep(1 * time.Second)
-			return SendTransaction(wallet, receiver, amount, sendMaximumAmount, -1, txData, gasParams, client)
+		txHexHash, txError := client.SendTransactionContext(ctx, tx.APIData)
+		if txError == nil {
+			client.Log().Info("transaction sent", logging.F("sender", wallet.Address), logging.F("nonce", tx.APIData.Nonce), logging.F("hash", txHexHash))
+			return tx, txHexHash, nil
 		}
 
-		return Transaction{}, "", err
-	}
+		// If we've sent an invalid nonce - back off and then retry again using a fresh nonce
+		if !errors.Is(txError, api.ErrInvalidNonce) || attempt >= policy.MaxAttempts {
+			return Transaction{}, "", txError
+		}
+
+		client.Log().Warn("transaction nonce rejected, resyncing nonce",
+			logging.F("sender", wallet.Address), logging.F("nonce", tx.APIData.Nonce), logging.F("attempt", attempt), logging.Err(txError))
 
-	return tx, txHexHash, nil
+		timer := time.NewTimer(policy.Backoff(attempt))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return Transaction{}, "", ctx.Err()
+		case <-timer.C:
+		}
+
+		nonce = -1
+	}
 }
 
 // GenerateAndSignTransaction - generates and signs a transaction
@@ -72,7 +107,25 @@ func GenerateAndSignTransaction(
 	gasParams GasParams,
 	client api.Client,
 ) (Transaction, error) {
-	tx, err := GenerateTransaction(wallet, receiver, amount, sendMaximumAmount, nonce, txData, gasParams, client)
+	return GenerateAndSignTransactionContext(context.Background(), wallet, receiver, amount, sendMaximumAmount, nonce, txData, gasParams, client)
+}
+
+// GenerateAndSignTransactionContext - generates and signs a transaction using the supplied context
+func GenerateAndSignTransactionContext(
+	ctx context.Context,
+	wallet sdkWallet.Wallet,
+	receiver string,
+	amount float64,
+	sendMaximumAmount bool,
+	nonce int64,
+	txData string,
+	gasParams GasParams,
+	client api.Client,
+) (Transaction, error) {
+	tx, err := GenerateTransactionContext(ctx, wallet, receiver, amount, sendMaximumAmount, nonce, txData, gasParams, client)
+	if err != nil {
+		return Transaction{}, err
+	}
 
 	signature, err := SignTransaction(wallet, tx)
 	if err != nil {
@@ -92,6 +145,9 @@ func GenerateAndSignTransaction(
 	txHexHash := hex.EncodeToString(txHash)
 	tx.TxHash = txHexHash
 
+	client.Log().Debug("signed transaction",
+		logging.F("sender", wallet.Address), logging.F("receiver", receiver), logging.F("nonce", tx.APIData.Nonce), logging.F("hash", txHexHash))
+
 	return tx, nil
 }
 
@@ -105,17 +161,41 @@ func GenerateTransaction(
 	txData string,
 	gasParams GasParams,
 	client api.Client,
+) (Transaction, error) {
+	return GenerateTransactionContext(context.Background(), wallet, receiver, amount, sendMaximumAmount, nonce, txData, gasParams, client)
+}
+
+// GenerateTransactionContext - generates a new transaction using the supplied parameters and context
+func GenerateTransactionContext(
+	ctx context.Context,
+	wallet sdkWallet.Wallet,
+	receiver string,
+	amount float64,
+	sendMaximumAmount bool,
+	nonce int64,
+	txData string,
+	gasParams GasParams,
+	client api.Client,
 ) (Transaction, error) {
 	receiverBytes, err := wallet.Converter.Decode(receiver)
+	if err != nil {
+		return Transaction{}, err
+	}
 
-	currentNonce, err := getNonce(client, wallet.Address, nonce)
+	currentNonce, err := getNonce(ctx, client, wallet.Address, nonce)
 	if err != nil {
 		return Transaction{}, err
 	}
 
 	gasParams.UpdateGasLimit(txData)
 
-	correctAmount, err := calculateAmount(client, wallet.Address, amount, sendMaximumAmount, gasParams)
+	if gasParams.EstimateGasLimit {
+		if err := estimateGasLimit(ctx, client, wallet.Address, receiver, amount, sendMaximumAmount, currentNonce, txData, &gasParams); err != nil {
+			return Transaction{}, err
+		}
+	}
+
+	correctAmount, err := calculateAmount(ctx, client, wallet.Address, amount, sendMaximumAmount, gasParams)
 	if err != nil {
 		return Transaction{}, err
 	}
@@ -161,13 +241,13 @@ func SignTransaction(wallet sdkWallet.Wallet, tx Transaction) ([]byte, error) {
 	return wallet.Sign(txBuff)
 }
 
-func getNonce(client api.Client, address string, nonce int64) (currentNonce uint64, err error) {
+func getNonce(ctx context.Context, client api.Client, address string, nonce int64) (currentNonce uint64, err error) {
 	if nonce >= 0 {
 		return uint64(nonce), nil
 	}
 
 	var account api.Account
-	account, err = client.GetAccount(address)
+	account, err = client.GetAccountContext(ctx, address)
 	if err != nil {
 		return 0, err
 	}
@@ -175,12 +255,12 @@ func getNonce(client api.Client, address string, nonce int64) (currentNonce uint
 	return uint64(account.Nonce), nil
 }
 
-func calculateAmount(client api.Client, address string, amount float64, sendMaximumAmount bool, gasParams GasParams) (correctAmount *big.Int, err error) {
+func calculateAmount(ctx context.Context, client api.Client, address string, amount float64, sendMaximumAmount bool, gasParams GasParams) (correctAmount *big.Int, err error) {
 	if !sendMaximumAmount {
 		return utils.ConvertFloatAmountToBigInt(amount), nil
 	}
 
-	account, err := client.GetAccount(address)
+	account, err := client.GetAccountContext(ctx, address)
 	if err != nil {
 		return nil, err
 	}
@@ -191,3 +271,85 @@ func calculateAmount(client api.Client, address string, amount float64, sendMaxi
 
 	return correctAmount, nil
 }
+
+func estimateGasLimit(
+	ctx context.Context,
+	client api.Client,
+	sender string,
+	receiver string,
+	amount float64,
+	sendMaximumAmount bool,
+	nonce uint64,
+	txData string,
+	gasParams *GasParams,
+) error {
+	value := "0"
+	if !sendMaximumAmount {
+		value = utils.ConvertFloatAmountToBigInt(amount).String()
+	}
+
+	estimate, err := client.EstimateTransactionCostContext(ctx, &api.TransactionData{
+		Sender:   sender,
+		Receiver: receiver,
+		Value:    value,
+		Data:     txData,
+		Nonce:    nonce,
+		GasPrice: gasParams.GasPrice,
+		GasLimit: gasParams.GasLimit,
+	})
+	if err != nil {
+		return err
+	}
+
+	gasParams.ApplyGasEstimate(estimate)
+
+	return nil
+}
+
+// SimulateTransaction - generates and signs a transaction and simulates it against the current state without broadcasting it
+func SimulateTransaction(
+	wallet sdkWallet.Wallet,
+	receiver string,
+	amount float64,
+	sendMaximumAmount bool,
+	nonce int64,
+	txData string,
+	gasParams GasParams,
+	client api.Client,
+) (Transaction, api.SimulationResult, error) {
+	return SimulateTransactionContext(context.Background(), wallet, receiver, amount, sendMaximumAmount, nonce, txData, gasParams, client)
+}
+
+// SimulateTransactionContext - generates and signs a transaction and simulates it against the current state using the supplied context
+// The gas used is estimated by the node for transactions that would execute successfully
+func SimulateTransactionContext(
+	ctx context.Context,
+	wallet sdkWallet.Wallet,
+	receiver string,
+	amount float64,
+	sendMaximumAmount bool,
+	nonce int64,
+	txData string,
+	gasParams GasParams,
+	client api.Client,
+) (Transaction, api.SimulationResult, error) {
+	tx, err := GenerateAndSignTransactionContext(ctx, wallet, receiver, amount, sendMaximumAmount, nonce, txData, gasParams, client)
+	if err != nil {
+		return Transaction{}, api.SimulationResult{}, err
+	}
+
+	result, err := client.SimulateTransactionContext(ctx, tx.APIData)
+	if err != nil {
+		return tx, result, err
+	}
+
+	if result.GasUsed == 0 && result.Successful() {
+		gasUsed, err := client.EstimateTransactionCostContext(ctx, tx.APIData)
+		if err != nil {
+			return tx, result, err
+		}
+		result.GasUsed = gasUsed
+	}
+
+	return tx, result, nil
+}
@@ -0,0 +1,154 @@
+package transactions_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/SebastianJ/elrond-sdk/api"
+	"github.com/SebastianJ/elrond-sdk/api/apitest"
+	"github.com/SebastianJ/elrond-sdk/transactions"
+	"github.com/SebastianJ/elrond-sdk/utils"
+	sdkWallet "github.com/SebastianJ/elrond-sdk/wallet"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSimulateTransaction(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/transaction/simulate":
+			w.Write([]byte(`{"data":{"result":{"status":"success","hash":"abc","scResults":{"def":{"hash":"def","data":"@6f6b@0f4240@68656c6c6f"}}}},"code":"successful"}`))
+		case "/transaction/cost":
+			w.Write([]byte(`{"txGasUnits":1250000}`))
+		default:
+			w.Write([]byte(`{"account":{"nonce":1,"balance":"0"}}`))
+		}
+	}))
+	defer server.Close()
+
+	wallet, err := sdkWallet.Generate()
+	assert.Nil(t, err)
+
+	tx, result, err := transactions.SimulateTransaction(wallet, wallet.Address, 0, false, -1, "getValue", transactions.DefaultGasParams, api.Client{Host: server.URL})
+	assert.Nil(t, err)
+	assert.NotEmpty(t, tx.APIData.Signature)
+	assert.True(t, result.Successful())
+	assert.Equal(t, uint64(1250000), result.GasUsed)
+
+	returnData, err := result.ReturnData()
+	assert.Nil(t, err)
+	assert.Equal(t, [][]byte{{0x0f, 0x42, 0x40}, []byte("hello")}, returnData)
+}
+
+func TestSimulateFailingTransaction(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/transaction/simulate":
+			w.Write([]byte(`{"data":{"result":{"senderShard":{"status":"success"},"receiverShard":{"status":"fail","failReason":"insufficient funds"}}}}`))
+		default:
+			w.Write([]byte(`{"account":{"nonce":1,"balance":"0"}}`))
+		}
+	}))
+	defer server.Close()
+
+	wallet, err := sdkWallet.Generate()
+	assert.Nil(t, err)
+
+	_, result, err := transactions.SimulateTransaction(wallet, wallet.Address, 10, false, -1, "", transactions.DefaultGasParams, api.Client{Host: server.URL})
+	assert.Nil(t, err)
+	assert.False(t, result.Successful())
+	assert.Equal(t, "insufficient funds", result.ReceiverShard.FailReason)
+	assert.Equal(t, uint64(0), result.GasUsed)
+}
+
+func TestSendTransactionResyncsCachedNonce(t *testing.T) {
+	t.Parallel()
+
+	node := apitest.NewNode(apitest.DefaultOptions)
+	defer node.Close()
+
+	wallet, err := sdkWallet.Generate()
+	assert.Nil(t, err)
+	node.Fund(wallet.Address, utils.ConvertFloatAmountToBigInt(10))
+
+	client := node.Client()
+	client.Cache = api.NewCache(nil)
+	client.RetryPolicy = &api.RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond}
+
+	// Warm the cache, then move the nonce on the node so the cached account is stale
+	account, err := client.GetAccount(wallet.Address)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(0), account.Nonce)
+	_, balance := node.Account(wallet.Address)
+	node.SetAccount(wallet.Address, 4, balance)
+
+	tx, _, err := transactions.SendTransaction(wallet, wallet.Address, 1, false, -1, "", transactions.DefaultGasParams, client)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(4), tx.APIData.Nonce)
+
+	nonce, _ := node.Account(wallet.Address)
+	assert.Equal(t, uint64(5), nonce)
+}
+
+func TestSendTransactionStopsAfterMaxAttempts(t *testing.T) {
+	t.Parallel()
+
+	var sends int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/transaction/send":
+			atomic.AddInt32(&sends, 1)
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"error":"transaction generation failed: invalid nonce"}`))
+		default:
+			w.Write([]byte(`{"account":{"nonce":1,"balance":"0"}}`))
+		}
+	}))
+	defer server.Close()
+
+	wallet, err := sdkWallet.Generate()
+	assert.Nil(t, err)
+
+	policy := &api.RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond}
+	client := api.Client{Host: server.URL, RetryPolicy: policy}
+
+	_, _, err = transactions.SendTransaction(wallet, wallet.Address, 1, false, -1, "", transactions.DefaultGasParams, client)
+	assert.True(t, errors.Is(err, api.ErrInvalidNonce), "%v", err)
+	assert.Equal(t, int32(policy.MaxAttempts), atomic.LoadInt32(&sends))
+}
+
+func TestSendTransactionBackoffHonoursContext(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/transaction/send":
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"error":"invalid nonce"}`))
+		default:
+			w.Write([]byte(`{"account":{"nonce":1,"balance":"0"}}`))
+		}
+	}))
+	defer server.Close()
+
+	wallet, err := sdkWallet.Generate()
+	assert.Nil(t, err)
+
+	client := api.Client{Host: server.URL, RetryPolicy: &api.RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Hour}}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, _, err = transactions.SendTransactionContext(ctx, wallet, wallet.Address, 1, false, -1, "", transactions.DefaultGasParams, client)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded), "%v", err)
+	assert.True(t, time.Since(start) < 5*time.Second)
+}
@@ -1,9 +1,11 @@
 package transactions
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
+	"github.com/SebastianJ/elrond-sdk/api"
 	"github.com/SebastianJ/elrond-sdk/crypto"
 )
 
@@ -62,3 +64,18 @@ func GenerateClaimPayload() string {
 func generateStakingPayload(command string, blsKey crypto.Key) string {
 	return fmt.Sprintf("%s@%s", command, blsKey.PublicKeyString)
 }
+
+// GetValidatorInfo - looks up the heartbeat and statistics of BLS keys generated using crypto.GenerateBlsKeys
+func GetValidatorInfo(client api.Client, blsKeys []crypto.Key) (map[string]api.ValidatorInfo, error) {
+	return GetValidatorInfoContext(context.Background(), client, blsKeys)
+}
+
+// GetValidatorInfoContext - looks up the heartbeat and statistics of BLS keys using the supplied context
+func GetValidatorInfoContext(ctx context.Context, client api.Client, blsKeys []crypto.Key) (map[string]api.ValidatorInfo, error) {
+	publicKeys := make([]string, len(blsKeys))
+	for index, blsKey := range blsKeys {
+		publicKeys[index] = blsKey.PublicKeyString
+	}
+
+	return client.GetValidatorInfoContext(ctx, publicKeys)
+}
@@ -1,8 +1,11 @@
 package transactions_test
 
 import (
+	"net/http"
+	"net/http/httptest"
 	"testing"
 
+	"github.com/SebastianJ/elrond-sdk/api"
 	"github.com/SebastianJ/elrond-sdk/crypto"
 	"github.com/SebastianJ/elrond-sdk/transactions"
 	"github.com/stretchr/testify/assert"
@@ -35,3 +38,25 @@ func TestGenerateCreateValidatorPayloadUsingRandomBlsKeys(t *testing.T) {
 
 	//t.Errorf("%s", payload)
 }
+
+func TestGetValidatorInfoForBlsKeys(t *testing.T) {
+	t.Parallel()
+
+	blsKeys, err := crypto.GenerateBlsKeys(2)
+	assert.Nil(t, err)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/node/heartbeatstatus":
+			w.Write([]byte(`{"heartbeats":[{"publicKey":"` + blsKeys[0].PublicKeyString + `","isActive":true}]}`))
+		default:
+			w.Write([]byte(`{"statistics":{}}`))
+		}
+	}))
+	defer server.Close()
+
+	validators, err := transactions.GetValidatorInfo(api.Client{Host: server.URL}, blsKeys)
+	assert.Nil(t, err)
+	assert.True(t, validators[blsKeys[0].PublicKeyString].Online())
+	assert.False(t, validators[blsKeys[1].PublicKeyString].Online())
+}
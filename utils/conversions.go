@@ -5,10 +5,20 @@ import (
 	"math/big"
 )
 
+const (
+	// DefaultDenomination - the number of decimals used by EGLD
+	DefaultDenomination = 18
+)
+
 // ConvertFloatAmountToBigInt - converts a given float64 amount to a bigint with the correct base
 func ConvertFloatAmountToBigInt(amount float64) *big.Int {
+	return ConvertFloatAmountToBigIntWithDecimals(amount, DefaultDenomination)
+}
+
+// ConvertFloatAmountToBigIntWithDecimals - converts a given float64 amount to a bigint using the base of the given number of decimals
+func ConvertFloatAmountToBigIntWithDecimals(amount float64, decimals int) *big.Int {
 	bigAmount := new(big.Float).SetFloat64(amount)
-	base := new(big.Float).SetInt(big.NewInt(1000000000000000000))
+	base := new(big.Float).SetInt(denominationBase(decimals))
 	bigAmount.Mul(bigAmount, base)
 	realAmount := new(big.Int)
 	bigAmount.Int(realAmount)
@@ -18,6 +28,11 @@ func ConvertFloatAmountToBigInt(amount float64) *big.Int {
 
 // ConvertNumeralStringToBigFloat - converts a numeral string back to a big float with the correct base set
 func ConvertNumeralStringToBigFloat(balance string) (*big.Float, error) {
+	return ConvertNumeralStringToBigFloatWithDecimals(balance, DefaultDenomination)
+}
+
+// ConvertNumeralStringToBigFloatWithDecimals - converts a numeral string back to a big float using the base of the given number of decimals
+func ConvertNumeralStringToBigFloatWithDecimals(balance string, decimals int) (*big.Float, error) {
 	floatBalance := new(big.Float)
 	floatBalance, ok := floatBalance.SetString(balance)
 
@@ -25,7 +40,15 @@ func ConvertNumeralStringToBigFloat(balance string) (*big.Float, error) {
 		return nil, fmt.Errorf("can't convert balance string %s to a float balance", balance)
 	}
 
-	base := new(big.Float).SetInt(big.NewInt(1000000000000000000))
+	base := new(big.Float).SetInt(denominationBase(decimals))
 	value := new(big.Float).Quo(floatBalance, base)
 	return value, nil
 }
+
+func denominationBase(decimals int) *big.Int {
+	if decimals < 0 {
+		decimals = 0
+	}
+
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil)
+}
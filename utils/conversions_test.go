@@ -0,0 +1,56 @@
+package utils_test
+
+import (
+	"testing"
+
+	"github.com/SebastianJ/elrond-sdk/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConvertFloatAmountToBigIntWithDecimals(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		amount   float64
+		decimals int
+		expected string
+	}{
+		{amount: 42, decimals: 0, expected: "42"},
+		{amount: 1.9, decimals: 0, expected: "1"},
+		{amount: 1.5, decimals: 6, expected: "1500000"},
+		{amount: 0.000001, decimals: 6, expected: "1"},
+		{amount: 2.5, decimals: 18, expected: "2500000000000000000"},
+		{amount: 0, decimals: 18, expected: "0"},
+	}
+
+	for _, testCase := range testCases {
+		assert.Equal(t, testCase.expected, utils.ConvertFloatAmountToBigIntWithDecimals(testCase.amount, testCase.decimals).String())
+	}
+}
+
+func TestConvertNumeralStringToBigFloatWithDecimals(t *testing.T) {
+	t.Parallel()
+
+	testCases := []struct {
+		balance  string
+		decimals int
+		expected float64
+	}{
+		{balance: "42", decimals: 0, expected: 42},
+		{balance: "1500000", decimals: 6, expected: 1.5},
+		{balance: "1", decimals: 6, expected: 0.000001},
+		{balance: "2500000000000000000", decimals: 18, expected: 2.5},
+		{balance: "0", decimals: 18, expected: 0},
+	}
+
+	for _, testCase := range testCases {
+		converted, err := utils.ConvertNumeralStringToBigFloatWithDecimals(testCase.balance, testCase.decimals)
+		assert.Nil(t, err)
+
+		value, _ := converted.Float64()
+		assert.InDelta(t, testCase.expected, value, 1e-12)
+	}
+
+	_, err := utils.ConvertNumeralStringToBigFloatWithDecimals("not a number", 18)
+	assert.NotNil(t, err)
+}
@@ -3,13 +3,15 @@ package utils
 import (
 	"fmt"
 	"path/filepath"
+
+	"github.com/SebastianJ/elrond-sdk/logging"
 )
 
 // IdentifyPemFiles - identify pem files from a specified path
 func IdentifyPemFiles(path string) ([]string, error) {
 	pattern := fmt.Sprintf("%s/*.pem", path)
 
-	fmt.Println("Pem pattern is now: ", pattern)
+	logging.Default().Debug("identifying pem files", logging.F("pattern", pattern))
 
 	keys, err := globFiles(pattern)
 
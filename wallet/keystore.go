@@ -4,6 +4,7 @@ import (
 	"io/ioutil"
 	"os"
 
+	"github.com/SebastianJ/elrond-sdk/logging"
 	ethKeystore "github.com/ethereum/go-ethereum/accounts/keystore"
 )
 
@@ -17,5 +18,7 @@ func DecryptKeystoreFile(path string, auth string) (*ethKeystore.Key, error) {
 
 	jsonData, _ := ioutil.ReadAll(file)
 
+	logging.Default().Debug("decrypting keystore", logging.F("path", path))
+
 	return ethKeystore.DecryptKey(jsonData, auth)
 }
@@ -7,6 +7,7 @@ import (
 	"github.com/ElrondNetwork/elrond-go/core"
 	"github.com/ElrondNetwork/elrond-go/core/pubkeyConverter"
 	"github.com/SebastianJ/elrond-sdk/crypto"
+	"github.com/SebastianJ/elrond-sdk/logging"
 )
 
 var (
@@ -38,7 +39,14 @@ func Decrypt(walletPath string) (Wallet, error) {
 		return Wallet{}, err
 	}
 
-	return toWallet(key)
+	wallet, err := toWallet(key)
+	if err != nil {
+		return Wallet{}, err
+	}
+
+	logging.Default().Debug("decrypted wallet", logging.F("path", walletPath), logging.F("address", wallet.Address))
+
+	return wallet, nil
 }
 
 // Generate - generate a new wallet
@@ -48,7 +56,14 @@ func Generate() (Wallet, error) {
 		return Wallet{}, err
 	}
 
-	return toWallet(key)
+	wallet, err := toWallet(key)
+	if err != nil {
+		return Wallet{}, err
+	}
+
+	logging.Default().Debug("generated wallet", logging.F("address", wallet.Address))
+
+	return wallet, nil
 }
 
 func toWallet(key crypto.Key) (Wallet, error) {
@@ -81,6 +96,7 @@ func toWallet(key crypto.Key) (Wallet, error) {
 func (wallet *Wallet) Sign(data []byte) ([]byte, error) {
 	signature, err := wallet.Key.Signer.Sign(wallet.Key.PrivateKey, data)
 	if err != nil {
+		logging.Default().Error("signing failed", logging.F("address", wallet.Address), logging.Err(err))
 		return nil, err
 	}
 